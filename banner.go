@@ -0,0 +1,124 @@
+package dio
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"runtime"
+	"strings"
+	"text/template"
+
+	"github.com/cheivin/dio-core"
+)
+
+// banner 输出方式（banner.mode / SetBannerMode）。
+const (
+	BannerModeConsole = "console" // 打印到标准输出（默认，日志组件创建之前）
+	BannerModeLog     = "log"     // 日志组件创建后以一条 INFO 日志输出（适合 JSON 日志）
+	BannerModeOff     = "off"     // 不输出
+)
+
+// BannerData banner 模板可引用的数据，如 {{.AppName}} {{.AppVersion}}。
+type BannerData struct {
+	AppName    string // app.name
+	AppVersion string // app.version
+	Profile    string // 当前 profile
+	GoVersion  string // 运行时 Go 版本
+	PID        int
+	Hostname   string
+}
+
+// SetBannerFile 从 fsys 中的 name 文件加载 banner 模板（Run 时读取，读取失败 Run 启动失败）。
+// 非并发安全，必须在 Run 前调用。
+func (d *dioContainer) SetBannerFile(fsys fs.FS, name string) core.Dio {
+	d.bannerFS, d.bannerFile = fsys, name
+	return d
+}
+
+// SetBannerMode 设置 banner 输出方式：BannerModeConsole（默认）、BannerModeLog 或 BannerModeOff。
+// 非并发安全，必须在 Run 前调用。
+func (d *dioContainer) SetBannerMode(mode string) core.Dio {
+	d.bannerMode = mode
+	return d
+}
+
+// bannerOutputMode 返回 banner 输出方式：banner.mode 配置优先于 SetBannerMode，未知取值返回错误。
+func (d *dioContainer) bannerOutputMode() (string, error) {
+	mode := d.GetPropertyString("banner.mode")
+	if mode == "" {
+		mode = d.bannerMode
+	}
+	switch mode {
+	case "":
+		return BannerModeConsole, nil
+	case BannerModeConsole, BannerModeLog, BannerModeOff:
+		return mode, nil
+	}
+	return "", fmt.Errorf("dio invalid banner mode %q, want %s/%s/%s", mode, BannerModeConsole, BannerModeLog, BannerModeOff)
+}
+
+// bannerSource 返回 banner 文本与是否按模板渲染，优先级：banner.text 配置 > banner.file 配置（文件路径）> SetBannerFile > SetBanner（默认 banner）。
+// SetBanner 设置的文本原样输出（ASCII 字符画中常见的 {{ 不会被当作模板）。
+func (d *dioContainer) bannerSource() (text string, templated bool, err error) {
+	if text := d.GetPropertyString("banner.text"); text != "" {
+		return text, true, nil
+	}
+	if file := d.GetPropertyString("banner.file"); file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", false, fmt.Errorf("dio load banner %q: %w", file, err)
+		}
+		return string(data), true, nil
+	}
+	if d.bannerFS != nil {
+		data, err := fs.ReadFile(d.bannerFS, d.bannerFile)
+		if err != nil {
+			return "", false, fmt.Errorf("dio load banner %q: %w", d.bannerFile, err)
+		}
+		return string(data), true, nil
+	}
+	return d.banner, false, nil
+}
+
+// renderBanner 返回 banner 输出方式与渲染结果（模板来源按 Go 模板渲染，去除末尾换行），没有 banner 或输出方式为 off 时为空串。
+func (d *dioContainer) renderBanner() (mode string, banner string, err error) {
+	if mode, err = d.bannerOutputMode(); err != nil || mode == BannerModeOff {
+		return mode, "", err
+	}
+	text, templated, err := d.bannerSource()
+	if err != nil || text == "" || !templated {
+		return mode, text, err
+	}
+	tmpl, err := template.New("banner").Parse(text)
+	if err != nil {
+		return mode, "", fmt.Errorf("dio invalid banner template: %w", err)
+	}
+	hostname, _ := os.Hostname()
+	data := BannerData{
+		AppName:    d.GetPropertyString("app.name"),
+		AppVersion: d.GetPropertyString("app.version"),
+		Profile:    d.Profile(),
+		GoVersion:  runtime.Version(),
+		PID:        os.Getpid(),
+		Hostname:   hostname,
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return mode, "", fmt.Errorf("dio invalid banner template: %w", err)
+	}
+	return mode, strings.TrimRight(b.String(), "\r\n"), nil
+}
+
+// printBanner 按输出方式输出 banner：console 在日志组件创建前打印到标准输出，log 在创建后输出到日志。
+func (d *dioContainer) printBanner(mode string, banner string, logReady bool) {
+	if banner == "" {
+		return
+	}
+	switch {
+	case mode == BannerModeLog && logReady:
+		d.log.Info(context.Background(), banner)
+	case mode == BannerModeConsole && !logReady:
+		fmt.Println(banner)
+	}
+}
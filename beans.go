@@ -0,0 +1,221 @@
+package dio
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// BeanKind bean 的注册形态。
+type BeanKind string
+
+const (
+	BeanKindInstance  BeanKind = "instance"  // 直接注册的实例（RegisterBean/RegisterNamedBean）
+	BeanKindPrototype BeanKind = "prototype" // 原型（Provide 系列，由 di 实例化并注入）
+	BeanKindFactory   BeanKind = "factory"   // 工厂函数（ProvideFunc）
+)
+
+// BeanInfo 单个 bean 的管理视图。
+type BeanInfo struct {
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`                // Go 类型（如 *service.UserService）
+	Kind         BeanKind `json:"kind"`                // 注册形态
+	Condition    string   `json:"condition,omitempty"` // 载入条件（ProvideOnProperty 系列，如 cache.type=redis），无条件为空
+	Dependencies []string `json:"dependencies"`        // 依赖的 bean（aware 注入与工厂函数入参，按名称排序）
+	Dependents   []string `json:"dependents"`          // 依赖该 bean 的 bean（按名称排序）
+}
+
+// BeanGraph bean 依赖关系图，可导出为 JSON（encoding/json）、Graphviz DOT 与 Mermaid。
+type BeanGraph struct {
+	Beans []BeanInfo `json:"beans"` // 按注册顺序
+}
+
+// beanOrigin 条件注册时记录的 bean 来源（Run 注册 providedBeans 时写入）。
+type beanOrigin struct {
+	condition string
+	typ       reflect.Type
+}
+
+// DescribeBeans 返回所有 bean 的管理视图与依赖关系图（名称、类型、注册形态、载入条件、依赖与被依赖）。
+// 原型 bean 在 Run 注册后才可见；依赖关系来自 bean 定义（GetBeanDependencies 的命名 aware 注入），
+// 以及按类型解析的未命名 aware 字段与工厂函数入参（同 di 注入时的规则：按注册顺序取第一个类型匹配的 bean）。
+func (d *dioContainer) DescribeBeans() BeanGraph {
+	names := d.GetBeanNames()
+	d.mu.Lock()
+	origins := make(map[string]beanOrigin, len(d.beanOrigins))
+	for name, origin := range d.beanOrigins {
+		origins[name] = origin
+	}
+	factoryInputs := make(map[string][]reflect.Type, len(d.factoryInputs))
+	for name, inputs := range d.factoryInputs {
+		factoryInputs[name] = inputs
+	}
+	d.mu.Unlock()
+
+	graph := BeanGraph{Beans: make([]BeanInfo, 0, len(names))}
+	index := make(map[string]int, len(names))
+	types := make([]reflect.Type, 0, len(names))
+	for _, name := range names {
+		info := BeanInfo{Name: name, Kind: BeanKindInstance, Condition: origins[name].condition, Dependents: []string{}}
+		typ := origins[name].typ
+		if desc, ok := d.DescribeBean(name); ok {
+			info.Kind = BeanKindPrototype
+			if desc.Factory {
+				info.Kind = BeanKindFactory
+			}
+			typ = desc.Type
+		} else if instance, ok := d.GetBean(name); ok && typ == nil {
+			typ = reflect.TypeOf(instance)
+		}
+		if typ != nil {
+			info.Type = typ.String()
+		}
+		if deps, ok := d.GetBeanDependencies(name); ok {
+			info.Dependencies = deps
+		}
+		index[name] = len(graph.Beans)
+		graph.Beans = append(graph.Beans, info)
+		types = append(types, typ)
+	}
+	// 按类型解析的依赖：未命名的 aware 字段与工厂函数入参
+	for i := range graph.Beans {
+		info := &graph.Beans[i]
+		var inputs []reflect.Type
+		if info.Kind == BeanKindFactory {
+			inputs = factoryInputs[info.Name]
+		} else if info.Kind == BeanKindPrototype {
+			inputs = awareTypes(types[i])
+		}
+		for _, input := range inputs {
+			if dep := resolveBeanByType(names, types, input); dep != "" && dep != info.Name && !slices.Contains(info.Dependencies, dep) {
+				info.Dependencies = append(info.Dependencies, dep)
+			}
+		}
+		if info.Dependencies == nil {
+			info.Dependencies = []string{}
+		}
+		sort.Strings(info.Dependencies)
+	}
+	for _, info := range graph.Beans {
+		for _, dep := range info.Dependencies {
+			if i, ok := index[dep]; ok {
+				graph.Beans[i].Dependents = append(graph.Beans[i].Dependents, info.Name)
+			}
+		}
+	}
+	for i := range graph.Beans {
+		sort.Strings(graph.Beans[i].Dependents)
+	}
+	return graph
+}
+
+// awareTypes 返回原型 bean 中未命名 aware 字段（aware:""，按类型注入）的字段类型。
+func awareTypes(t reflect.Type) (inputs []reflect.Type) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if tag, ok := field.Tag.Lookup("aware"); ok && strings.TrimSpace(strings.Split(tag, ",")[0]) == "" {
+			inputs = append(inputs, field.Type)
+		}
+	}
+	return inputs
+}
+
+// resolveBeanByType 按注册顺序返回第一个可赋值给 target 的 bean（原型类型按其指针形式匹配），没有时返回空串。
+func resolveBeanByType(names []string, types []reflect.Type, target reflect.Type) string {
+	for i, t := range types {
+		if t == nil {
+			continue
+		}
+		if t.AssignableTo(target) || (t.Kind() != reflect.Pointer && reflect.PointerTo(t).AssignableTo(target)) {
+			return names[i]
+		}
+	}
+	return ""
+}
+
+// DOT 导出为 Graphviz DOT（边由依赖方指向被依赖方）。
+func (g BeanGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph beans {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	for _, info := range g.Beans {
+		fmt.Fprintf(&b, "\t%s [label=%s];\n", dotQuote(info.Name), dotQuote(info.label("\n")))
+	}
+	for _, info := range g.Beans {
+		for _, dep := range info.Dependencies {
+			fmt.Fprintf(&b, "\t%s -> %s;\n", dotQuote(info.Name), dotQuote(dep))
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Mermaid 导出为 Mermaid flowchart（边由依赖方指向被依赖方）。
+// bean 名称可能包含 Mermaid 保留字符，节点统一使用 n0/n1… 作为 id。
+func (g BeanGraph) Mermaid() string {
+	ids := make(map[string]string, len(g.Beans))
+	id := func(name string) string {
+		if v, ok := ids[name]; ok {
+			return v
+		}
+		ids[name] = fmt.Sprintf("n%d", len(ids))
+		return ids[name]
+	}
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, info := range g.Beans {
+		fmt.Fprintf(&b, "\t%s[\"%s\"]\n", id(info.Name), mermaidEscape(info.label("<br/>")))
+	}
+	for _, info := range g.Beans {
+		for _, dep := range info.Dependencies {
+			if _, ok := ids[dep]; !ok {
+				fmt.Fprintf(&b, "\t%s[\"%s\"]\n", id(dep), mermaidEscape(dep))
+			}
+			fmt.Fprintf(&b, "\t%s --> %s\n", id(info.Name), id(dep))
+		}
+	}
+	return b.String()
+}
+
+// label 节点标签：名称、类型/形态与载入条件，以 sep 分行。
+func (info BeanInfo) label(sep string) string {
+	label := info.Name + sep + string(info.Kind)
+	if info.Type != "" {
+		label += " " + info.Type
+	}
+	if info.Condition != "" {
+		label += sep + "if " + info.Condition
+	}
+	return label
+}
+
+// condition 返回条件注册的可读描述（如 cache.type=redis / cache.type!=redis），无条件为空串。
+func (b bean) condition() string {
+	if b.property == "" {
+		return ""
+	}
+	op := "="
+	if !b.needMatch {
+		op = "!="
+	}
+	condition := b.property + op + b.compareValue
+	if b.caseInsensitive {
+		condition += " (ignore case)"
+	}
+	return condition
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"log/slog"
 	"os"
 	"os/signal"
 	"path"
@@ -28,14 +29,39 @@ type dioContainer struct {
 	loaded         bool
 	shutdownFns    []func()         // 优雅停机回调（Serve 退出后倒序执行）
 	state          AppState         // 应用生命周期状态（Pending→…→Stopped/Failed 单向推进）
-	stateChangeFns []func(AppState) // 状态变更回调（状态推进时快照后锁外倒序执行）
+	stateChangeFns []*stateListener // 状态变更回调（状态推进时快照后锁外倒序投递）
 	banner         string           // 启动 banner（空串不打印）
+	bannerFS       fs.FS            // banner 模板文件所在文件系统（SetBannerFile）
+	bannerFile     string           // banner 模板文件名（SetBannerFile）
+	bannerMode     string           // banner 输出方式（SetBannerMode，空串为 console）
 	startTime      time.Time        // Run 开始时间（启动耗时统计起点）
 	profile        string           // 显式设置的 profile（优先于环境变量 APP_PROFILE）
 	requiredProps  []string         // 必填配置项（RequireProperties 声明，Run 启动时校验）
 	shutdownTimeout time.Duration   // 停机回调（OnShutdown）总超时，0 表示不限时
 	shutdownParallel bool           // 停机回调是否并行执行（默认顺序倒序）
-	mu             sync.Mutex       // 保护 providedBeans/shutdownFns/state/stateChangeFns/startTime/requiredProps 的并发读写
+	signalHandlers []signalHandler  // 自定义信号处理器（OnSignal 注册，仅 Running 状态下分发）
+	configSources  []func() error   // 已加载的配置文件来源（按加载顺序，ReloadConfig 时重放）
+	migrateEnv     bool             // 是否已开启 AutoMigrateEnv（ReloadConfig 重放后重新迁移环境变量）
+	failureAnalyzers []FailureAnalyzer // 自定义启动失败分析器（AddFailureAnalyzer 注册，优先于内置分析器）
+	failureCause   error            // 最近一次启动失败的原因（Failed 状态时有值）
+	createTime     time.Time        // 容器创建时间（首条状态变更记录的计时起点）
+	stateHistory   []StateTransition // 状态变更历史（按时间先后，含 Failed 的失败原因）
+	startupPhases  []PhaseTiming    // 最近一次 Run 的启动阶段耗时
+	startupTotal   time.Duration    // 最近一次 Run 的启动总耗时（进入 Running 时记录）
+	beanTimings    map[string]*BeanTiming // 逐 bean 启动耗时（注册/构造/初始化）
+	beanTypes      map[string]string      // bean 类型（包路径.类型名）到名称，采样生命周期回调耗时用
+	diOps          []func()         // 作用于 di 容器的配置/注册操作（按调用顺序，Restart 重建 di 容器时重放）
+	logCreated     bool             // 日志组件是否由 Run 创建（而非 SetLogger 设置）
+	previousSlog   *slog.Logger     // log.slog-default 替换前的 slog 默认 logger（停机后还原）
+	healthRuns     healthRunner     // 健康检查的并发槽位与进行中的检查（跨 CheckHealth 调用共享）
+	healthCache    *healthCache     // 后台健康检查缓存（health.background.interval 开启时 Running 期间有值）
+	propertyKeys   map[string]bool  // 设置过的顶层配置项（管理端点 /env 枚举用）
+	beanOrigins    map[string]beanOrigin // Run 注册的 bean 来源（载入条件/实例类型，DescribeBeans 用）
+	factoryInputs  map[string][]reflect.Type // 工厂 bean 的入参类型（工厂执行时记录，DescribeBeans 用）
+	contextExtractors []ContextFieldExtractor // 日志上下文字段提取器（AddContextFieldExtractor 注册）
+	logSinks          map[string]LogSinkFactory // 注册的日志输出类型（RegisterLogSink 插件注册）
+	propMu         sync.RWMutex     // 保护经 dio 写入的配置项与管理端点/Info 读取配置快照之间的并发
+	mu             sync.Mutex       // 保护 providedBeans/shutdownFns/state/stateChangeFns/startTime/requiredProps/signalHandlers/configSources 的并发读写
 }
 
 // AppState 应用生命周期状态，随容器启动/停机单向推进。
@@ -57,6 +83,11 @@ const (
 	Failed
 )
 
+// MarshalText 以状态名输出（JSON 序列化为 "Running" 等字符串）。
+func (s AppState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
 func (s AppState) String() string {
 	switch s {
 	case Pending:
@@ -95,17 +126,15 @@ var (
 	ErrAlreadyRun = errors.New("dio already run")
 	// ErrMissingProperty 必填配置项缺失（RequireProperties 校验未通过）
 	ErrMissingProperty = errors.New("dio missing property")
+	// ErrNotReady 容器未就绪（非 Running 状态时执行健康检查）
+	ErrNotReady = errors.New("dio not ready")
 )
 
 // 默认启动 banner，可通过 SetBanner 自定义或传空字符串关闭
 const defaultBanner = " ____    ______   _____      \n/\\  _`\\ /\\__  _\\ /\\  __`\\    \n\\ \\ \\/\\ \\/_/\\ \\/ \\ \\ \\/\\ \\   \n \\ \\ \\ \\ \\ \\ \\ \\  \\ \\ \\ \\ \\  \n  \\ \\_\\ \\ \\_\\ \\__\\ \\ \\_\\ \\ \n   \\ \\____/ /\\_____\\\\ \\_____\\\n    \\/___/  \\/_____/ \\/_____/"
 
-// healthCheckTimeout 单个健康检查的超时时间。
-// 可通过传给 Health 的 ctx deadline 收紧（整体时限），但不能放宽。
-const healthCheckTimeout = 5 * time.Second
-
 func New() core.Dio {
-	container := &dioContainer{di: di.New(), providedBeans: []bean{}, loaded: false, banner: defaultBanner}
+	container := &dioContainer{di: di.New(), providedBeans: []bean{}, loaded: false, banner: defaultBanner, createTime: time.Now()}
 	container.di.Log(emptyLogger{})
 	logName := "dio_app"
 	if hostname, err := os.Hostname(); err == nil && hostname != "" {
@@ -149,22 +178,26 @@ func (d *dioContainer) matchProperty(property string, compareValue string, needM
 }
 
 func (d *dioContainer) SetDefaultProperty(key string, value any) core.Dio {
-	d.di.SetDefaultProperty(key, value)
+	d.record(func() { d.writeProperty(func() { d.di.SetDefaultProperty(key, value) }) })
+	d.trackPropertyKeys(key)
 	return d
 }
 
 func (d *dioContainer) SetDefaultPropertyMap(properties map[string]any) core.Dio {
-	d.di.SetDefaultPropertyMap(properties)
+	d.record(func() { d.writeProperty(func() { d.di.SetDefaultPropertyMap(properties) }) })
+	d.trackPropertyKeys(mapKeys(properties)...)
 	return d
 }
 
 func (d *dioContainer) SetProperty(key string, value any) core.Dio {
-	d.di.SetProperty(key, value)
+	d.record(func() { d.writeProperty(func() { d.di.SetProperty(key, value) }) })
+	d.trackPropertyKeys(key)
 	return d
 }
 
 func (d *dioContainer) SetPropertyMap(properties map[string]any) core.Dio {
-	d.di.SetPropertyMap(properties)
+	d.record(func() { d.writeProperty(func() { d.di.SetPropertyMap(properties) }) })
+	d.trackPropertyKeys(mapKeys(properties)...)
 	return d
 }
 
@@ -186,7 +219,10 @@ func (d *dioContainer) GetProperties(prefix string, destType any) any {
 }
 
 func (d *dioContainer) AutoMigrateEnv() core.Dio {
-	d.di.AutoMigrateEnv()
+	d.mu.Lock()
+	d.migrateEnv = true
+	d.mu.Unlock()
+	d.record(func() { d.writeProperty(func() { d.di.AutoMigrateEnv() }) })
 	return d
 }
 
@@ -195,7 +231,8 @@ func (d *dioContainer) SetLogger(log core.Log) core.Dio {
 		panic(fmt.Errorf("%w: dioContainer is already run", ErrAlreadyRun))
 	}
 	d.log = log
-	d.di.RegisterBean(log)
+	d.logCreated = false
+	d.record(func() { d.di.RegisterBean(log) })
 	return d
 }
 
@@ -247,19 +284,26 @@ func (d *dioContainer) ProvideFunc(fn any) core.Dio {
 	if d.loaded {
 		panic(fmt.Errorf("%w: dioContainer is already run", ErrAlreadyRun))
 	}
-	d.di.ProvideFunc(fn)
+	// 包装工厂函数以统计构造耗时（见 StartupReport）
+	var registerCost time.Duration
+	factory := d.timedFactory(fn, &registerCost)
+	d.record(func() {
+		start := time.Now()
+		d.di.ProvideFunc(factory)
+		registerCost = time.Since(start)
+	})
 	return d
 }
 
 // WithCircularCheck 开启/关闭循环依赖检测，转发到底层 di 容器
 func (d *dioContainer) WithCircularCheck(enable bool) core.Dio {
-	d.di.WithCircularCheck(enable)
+	d.record(func() { d.di.WithCircularCheck(enable) })
 	return d
 }
 
 // WithBeanSelector 设置接口多实现选择策略，转发到底层 di 容器
 func (d *dioContainer) WithBeanSelector(s di.BeanSelector) core.Dio {
-	d.di.WithBeanSelector(s)
+	d.record(func() { d.di.WithBeanSelector(s) })
 	return d
 }
 
@@ -472,7 +516,7 @@ func (d *dioContainer) SetShutdownParallel(parallel bool) core.Dio {
 	return d
 }
 
-// SetBanner 设置启动 banner，Run 开始时打印到控制台。
+// SetBanner 设置启动 banner（原样输出，不按模板渲染），Run 开始时打印到控制台。
 // 传空字符串可关闭 banner。非并发安全，必须在 Run 前调用。
 func (d *dioContainer) SetBanner(banner string) core.Dio {
 	d.banner = banner
@@ -538,32 +582,45 @@ func (d *dioContainer) State() AppState {
 }
 
 // OnStateChange 注册状态变更回调，容器状态推进时倒序执行（回调在锁外调用）。
-// 可用于就绪探针、指标上报等。必须在 Run 前注册。
+// 可用于就绪探针、指标上报等。
+// Run 之后注册（如通过 Use 挂载的监控插件、bean 内注册）的回调会先在调用方 goroutine 中
+// 按时间顺序重放已发生的状态（含 Failed），之后的状态推进照常通知，不会遗漏 Starting。
+// 同一回调按状态历史顺序逐条收到通知、每条恰好一次：重放期间并发推进的状态排在重放之后投递；
+// 回调内再触发状态推进（如 Stopped 时 Restart）时，新状态在当前回调返回后投递。
 // 注意：Starting 在 bean 注册之前触发，此时容器内容不完整（仅记录启动开始事件）；
 // 需要就绪感知的请用 Running 状态或 Ready()。
 func (d *dioContainer) OnStateChange(fn func(AppState)) core.Dio {
+	// 注册与重放范围在同一把锁内确定：之后的状态推进一定能看到新回调，不重复也不遗漏
 	d.mu.Lock()
-	d.stateChangeFns = append(d.stateChangeFns, fn)
+	listener := &stateListener{fn: fn, replayTo: len(d.stateHistory)}
+	d.stateChangeFns = append(d.stateChangeFns, listener)
 	d.mu.Unlock()
+	d.deliverState(listener)
 	return d
 }
 
-// setState 推进应用状态并触发状态变更回调（快照后锁外倒序执行）。
+// setState 推进应用状态并触发状态变更回调（快照后锁外倒序投递）。
 // 状态只允许单向推进，回退或重复设置会 panic；
-// 唯一例外是 Failed 后可重新 Starting——配合 Run 的 defer 还原 loaded=false 实现启动失败重试。
+// 例外是 Failed/Stopped 后可重新 Starting——配合 Run 的 defer 还原 loaded=false 实现启动失败重试，
+// 以及 Restart 重置容器后再次启动（Stopped 后 loaded 仍为 true，直接 Run 会被拒绝）。
 func (d *dioContainer) setState(s AppState) {
 	d.mu.Lock()
-	if s <= d.state && !(d.state == Failed && s == Starting) {
+	if s <= d.state && !((d.state == Failed || d.state == Stopped) && s == Starting) {
 		old := d.state
 		d.mu.Unlock()
 		panic(fmt.Errorf("dio state cannot go backwards: %s -> %s", old, s))
 	}
+	d.recordTransition(d.state, s, nil)
 	d.state = s
-	fns := append([]func(AppState){}, d.stateChangeFns...)
+	if s == Starting {
+		// 重新启动（含 Failed 后重试）清空上次的失败原因
+		d.failureCause = nil
+	}
+	listeners := append([]*stateListener(nil), d.stateChangeFns...)
 	d.mu.Unlock()
 	// 回调在锁外倒序执行（回调可能反向调用容器方法，持锁会死锁）
-	for i := len(fns) - 1; i >= 0; i-- {
-		fns[i](s)
+	for i := len(listeners) - 1; i >= 0; i-- {
+		d.deliverState(listeners[i])
 	}
 }
 
@@ -575,56 +632,47 @@ func (d *dioContainer) Ready() bool {
 	return d.state == Running
 }
 
-// Health 健康检查：聚合容器内所有实现 core.HealthChecker 的 bean 的检查结果。
-// 每个检查独立超时（healthCheckTimeout），单个失败即返回聚合错误（errors.Join，可用 errors.Is 判断）。
-// 容器未就绪（Ready=false）时直接返回错误，避免在启动未完成时误报健康。
-func (d *dioContainer) Health(ctx context.Context) error {
-	if !d.Ready() {
-		return errors.New("dio not ready")
-	}
-	checkers := d.di.GetByTypeAll((*core.HealthChecker)(nil))
-	var errs []error
-	for _, checkerBean := range checkers {
-		// 容器自身注册为 bean 且实现 HealthChecker（Health 即聚合入口），跳过以避免无限递归
-		if checkerBean.Bean == d {
-			continue
-		}
-		checker := checkerBean.Bean.(core.HealthChecker)
-		// 每个检查独立超时；ctx 若带 deadline 会进一步收紧
-		checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
-		err := checker.Health(checkCtx)
-		cancel()
-		if err != nil {
-			errs = append(errs, fmt.Errorf("health check failed for %s: %w", checkerBean.Name, err))
-		}
-	}
-	return errors.Join(errs...)
-}
-
 func (d *dioContainer) Run(ctx context.Context, afterRunFns ...func(core.Dio)) {
 	if d.loaded {
-		panic(fmt.Errorf("%w: dioContainer is already run", ErrAlreadyRun))
+		// 重复 Run 不影响正在运行的容器状态，仅输出失败分析
+		err := fmt.Errorf("%w: dioContainer is already run", ErrAlreadyRun)
+		d.reportFailure(err)
+		panic(err)
 	}
 	d.loaded = true
 	d.mu.Lock()
 	d.startTime = time.Now()
+	d.startupPhases = nil
+	d.startupTotal = 0
+	phaseStart := d.startTime
 	d.mu.Unlock()
 
 	// panic 时还原 loaded 并关闭已创建的日志组件，避免状态残留与资源泄漏。
 	// 失败时状态直接置 Failed（不经 setState：回调在 panic 展开期执行可能再次 panic 掩盖原始错误），
-	// 调用方可通过 State() 感知失败。
+	// 调用方可通过 State() 感知失败、FailureCause() 获取原因；已知错误经 FailureAnalyzer 输出描述与建议动作。
 	// 注意：仅日志创建阶段的失败可修正后重试 Run；
 	// bean 注册/di.Load 之后的失败，di 容器已残留 bean 与 loaded 状态，重试会 panic。
 	defer func() {
 		if r := recover(); r != nil {
 			d.loaded = false
+			cause := panicError(r)
 			d.mu.Lock()
+			d.recordTransition(d.state, Failed, cause)
+			d.stateHistory[len(d.stateHistory)-1].silent = true
 			d.state = Failed
+			d.failureCause = cause
 			d.mu.Unlock()
+			d.reportFailure(cause)
+			d.restoreDefaultSlog()
 			if d.log != nil {
 				if disposable, ok := d.log.(Disposable); ok {
 					_ = disposable.Close()
 				}
+				// 已关闭的日志组件不再复用，重试 Run 时重新创建
+				if d.logCreated {
+					d.log = nil
+					d.logCreated = false
+				}
 			}
 			panic(r)
 		}
@@ -632,32 +680,61 @@ func (d *dioContainer) Run(ctx context.Context, afterRunFns ...func(core.Dio)) {
 
 	d.setState(Starting)
 
-	// 打印启动 banner（与日志组件无关，直接输出到控制台）
-	if d.banner != "" {
-		fmt.Println(d.banner)
+	// 渲染启动 banner：console 模式直接输出到控制台，log 模式待日志组件创建后输出
+	bannerMode, banner, err := d.renderBanner()
+	if err != nil {
+		panic(err)
 	}
+	d.printBanner(bannerMode, banner, false)
 
 	// 必填配置项校验：缺失则启动失败。放在日志创建之前，失败不污染 di 容器，修正后可重试
 	if missing := d.checkMissingProperties(); len(missing) > 0 {
 		panic(fmt.Errorf("%w: %s", ErrMissingProperty, strings.Join(missing, ", ")))
 	}
+	phaseStart = d.endPhase("prepare", phaseStart)
 
 	// 先创建日志组件再注册容器 bean：日志创建阶段的失败不污染 di 容器（未注册任何 bean），
 	// 修正后可重试 Run；bean 注册/di.Load 之后的失败，di 容器已残留 bean，重试会 panic。
+	// Restart 时复用上次 Run 创建的日志组件（SetLogger 设置的日志组件由 record 重放注册）
 	if d.log == nil {
 		property := d.GetProperties("log.", core.Property{}).(core.Property)
-		if log, err := NewZapLogger(property); err != nil {
+		config := d.GetProperties("log.", ZapConfig{}).(ZapConfig)
+		outputs, err := parseLogOutputs(d.di.Property().Get("log.outputs"))
+		if err != nil {
+			panic(err)
+		}
+		if log, err := newZapLogger(property, config, outputs, d.logSinkFactories()); err != nil {
 			panic(err)
 		} else {
 			d.log = log
+			d.logCreated = true
 		}
+	}
+	// log.level / log.levels.{name} 级别配置（日志组件支持调整级别时生效）
+	if err := d.applyLogLevels(); err != nil {
+		panic(err)
+	}
+	// log.redact.* 脱敏配置
+	if err := d.applyLogRedaction(); err != nil {
+		panic(err)
+	}
+	// log.sampling.* 采样配置
+	if err := d.applyLogSampling(); err != nil {
+		panic(err)
+	}
+	d.applyContextExtractors(false)
+	d.setDefaultSlog()
+	d.printBanner(bannerMode, banner, true)
+	if d.logCreated {
 		d.di.RegisterBean(d.log)
 	}
+	phaseStart = d.endPhase("logger", phaseStart)
 	d.di.RegisterBean(d)
 	d.di.Log(newDiLogger(d.log))
 
 	ctx, stop := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
+	stopSignals := d.serveSignals(ctx)
 
 	// 配置bean（锁内取快照，避免与并发注册 race）
 	d.mu.Lock()
@@ -665,31 +742,69 @@ func (d *dioContainer) Run(ctx context.Context, afterRunFns ...func(core.Dio)) {
 	d.mu.Unlock()
 	for _, beanDefinition := range providedBeans {
 		if beanDefinition.matchProperty(d) {
-			if beanDefinition.registered {
-				d.di.RegisterNamedBean(beanDefinition.name, beanDefinition.instance)
-			} else {
-				d.di.ProvideNamedBean(beanDefinition.name, beanDefinition.instance)
+			name := d.timedRegister(beanDefinition.name, beanDefinition.instance, func() {
+				if beanDefinition.registered {
+					d.di.RegisterNamedBean(beanDefinition.name, beanDefinition.instance)
+				} else {
+					d.di.ProvideNamedBean(beanDefinition.name, beanDefinition.instance)
+				}
+			})
+			if name != "" {
+				d.mu.Lock()
+				if d.beanOrigins == nil {
+					d.beanOrigins = map[string]beanOrigin{}
+				}
+				d.beanOrigins[name] = beanOrigin{condition: beanDefinition.condition(), typ: reflect.TypeOf(beanDefinition.instance)}
+				d.mu.Unlock()
 			}
 		}
 	}
+	phaseStart = d.endPhase("register", phaseStart)
 
-	// 启动容器
-	d.di.Load()
+	// 启动容器（Load 期间采样 bean 生命周期回调的耗时，见 StartupReport）
+	func() {
+		defer d.sampleBeanCallbacks()()
+		d.di.Load()
+	}()
+	// 容器加载后追加实现 ContextFieldExtractor 的 bean
+	d.applyContextExtractors(true)
+	phaseStart = d.endPhase("load", phaseStart)
 
 	// 容器加载完成后执行的方法
 	for _, fn := range afterRunFns {
 		fn(d)
 	}
-	// 进入 Running 状态（触发 OnStateChange 回调），并输出启动摘要（bean 数/耗时/profile）
+	d.endPhase("after-run", phaseStart)
+	d.mu.Lock()
+	d.startupTotal = time.Since(d.startTime)
+	d.mu.Unlock()
+	// 进入 Running 状态（触发 OnStateChange 回调），并输出启动摘要（bean 数/耗时/profile）与慢 bean 报告
 	d.setState(Running)
 	summary := fmt.Sprintf("started in %s, %d beans", d.StartupDuration(), len(d.di.GetBeanNames()))
 	if profile := d.Profile(); profile != "" {
 		summary += fmt.Sprintf(", profile: %s", profile)
 	}
 	d.log.Info(context.Background(), summary)
-
-	// 阻塞等待 ctx 结束；di.Serve 退出时内部已倒序销毁 bean（触发 Destroy 回调）
-	d.di.Serve(ctx)
+	d.logInfo(context.Background())
+	d.logStartupReport(context.Background())
+	stopHealth := d.serveHealth(ctx)
+	stopManagement := d.serveManagement()
+
+	// ctx 结束时先关闭管理端点、停止后台健康检查，再通知 di.Serve 销毁 bean：
+	// 避免端点请求与后台轮询访问已销毁的 bean。serveCtx 保留 ctx 的值，取消由上述顺序控制
+	serveCtx, cancelServe := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelServe()
+	go func() {
+		<-ctx.Done()
+		stopManagement()
+		stopHealth()
+		cancelServe()
+	}()
+	// 阻塞等待 serveCtx 结束；di.Serve 退出时内部已倒序销毁 bean（触发 Destroy 回调）
+	d.di.Serve(serveCtx)
+	stopSignals()
+	// 日志组件已随 bean 销毁关闭，还原进程级的 slog 默认 logger
+	d.restoreDefaultSlog()
 
 	// Serve 退出：进入停机阶段，执行停机回调（bean 已在 di.Serve 内部销毁）
 	d.setState(Stopping)
@@ -778,11 +893,14 @@ func profileConfigFilename(filename string, profile string) string {
 }
 
 func (d *dioContainer) LoadDefaultConfig(configs fs.FS, filename string) core.Dio {
-	configMap, err := loadConfigMap(configs, filename)
-	if err != nil {
-		panic(err)
-	}
-	d.SetDefaultPropertyMap(configMap)
+	d.addConfigSource(func() error {
+		configMap, err := loadConfigMap(configs, filename)
+		if err != nil {
+			return err
+		}
+		d.loadPropertyMap(configMap, true)
+		return nil
+	})
 	return d
 }
 
@@ -793,23 +911,26 @@ func (d *dioContainer) LoadDefaultConfig(configs fs.FS, filename string) core.Di
 //   - AutoMigrateEnv 环境变量、SetProperty/SetPropertyMap 显式配置：最高优先级（同级，后写覆盖先写）
 
 func (d *dioContainer) LoadConfig(configs fs.FS, filename string) core.Dio {
-	// 公共配置为低优先级（SetDefault），profile 覆盖配置为高优先级（Set），优先级链见上方注释
-	configMap, err := loadConfigMap(configs, filename)
-	if err != nil {
-		panic(err)
-	}
-	d.SetDefaultPropertyMap(configMap)
-	// 自动尝试加载 profile 覆盖配置（如 config-dev.yaml），文件不存在时忽略
-	if profile := d.Profile(); profile != "" {
-		profileConfigMap, err := loadConfigMap(configs, profileConfigFilename(filename, profile))
+	d.addConfigSource(func() error {
+		// 公共配置为低优先级（SetDefault），profile 覆盖配置为高优先级（Set），优先级链见上方注释
+		configMap, err := loadConfigMap(configs, filename)
 		if err != nil {
-			if !errors.Is(err, fs.ErrNotExist) {
-				panic(err)
+			return err
+		}
+		d.loadPropertyMap(configMap, true)
+		// 自动尝试加载 profile 覆盖配置（如 config-dev.yaml），文件不存在时忽略
+		if profile := d.Profile(); profile != "" {
+			profileConfigMap, err := loadConfigMap(configs, profileConfigFilename(filename, profile))
+			if err != nil {
+				if !errors.Is(err, fs.ErrNotExist) {
+					return err
+				}
+			} else {
+				d.loadPropertyMap(profileConfigMap, false)
 			}
-		} else {
-			d.SetPropertyMap(profileConfigMap)
 		}
-	}
+		return nil
+	})
 	return d
 }
 
@@ -817,24 +938,73 @@ func (d *dioContainer) LoadConfig(configs fs.FS, filename string) core.Dio {
 // 全部作为公共配置（SetDefault 优先级）合并。目录不存在或文件解析失败会 panic。
 // 注意：目录加载不区分 profile，按 profile 覆盖请使用 LoadConfig 的 config-{profile}.yaml 约定。
 func (d *dioContainer) LoadConfigDir(configs fs.FS, dir string) core.Dio {
-	entries, err := fs.ReadDir(configs, dir)
-	if err != nil {
-		panic(err)
-	}
-	filenames := make([]string, 0, len(entries))
-	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
-			continue
-		}
-		filenames = append(filenames, entry.Name())
-	}
-	sort.Strings(filenames)
-	for _, filename := range filenames {
-		configMap, err := loadConfigMap(configs, path.Join(dir, filename))
+	d.addConfigSource(func() error {
+		entries, err := fs.ReadDir(configs, dir)
 		if err != nil {
+			return err
+		}
+		filenames := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+				continue
+			}
+			filenames = append(filenames, entry.Name())
+		}
+		sort.Strings(filenames)
+		for _, filename := range filenames {
+			configMap, err := loadConfigMap(configs, path.Join(dir, filename))
+			if err != nil {
+				return err
+			}
+			d.loadPropertyMap(configMap, true)
+		}
+		return nil
+	})
+	return d
+}
+
+// addConfigSource 立即执行一次配置加载（失败 panic），成功后记录该来源供 ReloadConfig 重放；
+// 来源整体作为一次 di 操作记录，Restart 重建 di 容器时按原顺序重新读取配置文件。
+func (d *dioContainer) addConfigSource(load func() error) {
+	d.record(func() {
+		if err := load(); err != nil {
 			panic(err)
 		}
-		d.SetDefaultPropertyMap(configMap)
+	})
+	d.mu.Lock()
+	d.configSources = append(d.configSources, load)
+	d.mu.Unlock()
+}
+
+// loadPropertyMap 写入配置文件中的配置项（defaults 为 true 时为默认配置优先级）。
+// 不单独记录为 di 操作：配置来源由 addConfigSource 整体记录，避免 ReloadConfig 每次重放都累积记录。
+func (d *dioContainer) loadPropertyMap(properties map[string]any, defaults bool) {
+	d.writeProperty(func() {
+		if defaults {
+			d.di.SetDefaultPropertyMap(properties)
+		} else {
+			d.di.SetPropertyMap(properties)
+		}
+	})
+	d.trackPropertyKeys(mapKeys(properties)...)
+}
+
+// ReloadConfig 按原加载顺序重新读取 LoadDefaultConfig/LoadConfig/LoadConfigDir 加载过的配置文件，
+// 开启过 AutoMigrateEnv 时随后重新迁移环境变量（保持环境变量的最高优先级）。
+// 重放只覆盖/新增配置项，文件中已删除的配置项不会被移除；任一来源读取失败即返回错误（此前的来源已生效）。
+// 注意：与 profile 覆盖配置同级的 SetProperty 显式配置，若与 profile 文件同名会被重放覆盖。
+func (d *dioContainer) ReloadConfig() error {
+	d.mu.Lock()
+	sources := append([]func() error(nil), d.configSources...)
+	migrateEnv := d.migrateEnv
+	d.mu.Unlock()
+	for _, load := range sources {
+		if err := load(); err != nil {
+			return err
+		}
 	}
-	return d
+	if migrateEnv {
+		d.writeProperty(func() { d.di.AutoMigrateEnv() })
+	}
+	return nil
 }
@@ -39,7 +39,8 @@ func newDiLogger(log core.Log) di.Log {
 }
 
 func (d dioLogger) DebugMode(_ bool) {
-	// di 的 DebugMode 对 dio 无意义：dio 的调试级别由 zap 的 log.debug 配置控制
+	// di 的 DebugMode 对 dio 无意义：dio 的级别由 log.level / log.levels 配置或 SetLogLevel 控制
+	// （di 内部日志使用 "[DIO]" 前缀，可通过 SetLogLevel("[DIO]", "debug") 单独调整）
 }
 
 func (d dioLogger) Debug(s string) {
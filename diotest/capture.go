@@ -0,0 +1,288 @@
+// Package diotest 提供测试 dio 应用的辅助工具。
+package diotest
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+// 捕获日志的级别。
+const (
+	LevelDebug = "debug"
+	LevelInfo  = "info"
+	LevelWarn  = "warn"
+	LevelError = "error"
+)
+
+// Entry 一条捕获的日志。
+type Entry struct {
+	Time    time.Time
+	Level   string         // debug/info/warn/error
+	Message string         //
+	Fields  map[string]any // keyAndValues 与 map 形式的字段（同名 key 后者覆盖前者，落单的 key 记为 !BADKEY）
+	Logger  string         // Named 名称（多级以 . 连接），根 logger 为空
+	TraceID string         // ctx 中的 trace id（Trace/TraceWith 写入），没有时为空
+}
+
+// String 以 "level [logger] message {fields}" 的形式输出，用于断言失败时的提示。
+func (e Entry) String() string {
+	var b strings.Builder
+	b.WriteString(e.Level)
+	if e.Logger != "" {
+		b.WriteString(" [" + e.Logger + "]")
+	}
+	b.WriteString(" " + e.Message)
+	if len(e.Fields) > 0 {
+		fmt.Fprintf(&b, " %v", e.Fields)
+	}
+	if e.TraceID != "" {
+		b.WriteString(" trace_id=" + e.TraceID)
+	}
+	return b.String()
+}
+
+// captureStore 捕获的日志，在 Named/Skip 派生的 logger 间共享。
+type captureStore struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// CaptureLogger 在内存中记录日志的 core.Log 实现，用于断言容器与 bean 输出的日志，不写文件也不输出到控制台。
+//
+//	capture := diotest.NewCaptureLogger()
+//	dio.SetLogger(capture)
+//	...
+//	capture.AssertLogged(t, diotest.LevelInfo, "order paid", "orderId", 1001)
+type CaptureLogger struct {
+	store *captureStore
+	name  string
+}
+
+// NewCaptureLogger 创建捕获日志组件。
+func NewCaptureLogger() *CaptureLogger {
+	return &CaptureLogger{store: &captureStore{}}
+}
+
+// BeanName 与 ZapLogger 一致，以 "log" 注册到容器。
+func (l *CaptureLogger) BeanName() string {
+	return "log"
+}
+
+func (l *CaptureLogger) Named(named string) core.Log {
+	name := named
+	if l.name != "" {
+		name = l.name + "." + named
+	}
+	return &CaptureLogger{store: l.store, name: name}
+}
+
+func (l *CaptureLogger) Skip(int) core.Log {
+	return l
+}
+
+func (l *CaptureLogger) Logger() any {
+	return l
+}
+
+// captureTraceKey 追踪上下文在 ctx 中的 key。
+type captureTraceKey struct{}
+
+// Trace ctx 中没有追踪上下文时生成新的（W3C 格式）。
+func (l *CaptureLogger) Trace(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(captureTraceKey{}).(dio.TraceContext); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, captureTraceKey{}, dio.NewTraceContext())
+}
+
+// TraceWith 使用指定的追踪上下文：val 可为 dio.TraceContext、W3C traceparent 或其他字符串（原值作为 trace id）。
+func (l *CaptureLogger) TraceWith(ctx context.Context, val any) context.Context {
+	switch v := val.(type) {
+	case dio.TraceContext:
+		return context.WithValue(ctx, captureTraceKey{}, v)
+	case string:
+		if v == "" {
+			return ctx
+		}
+		trace, err := dio.ParseTraceParent(v)
+		if err != nil {
+			trace = dio.TraceContext{TraceID: v}
+		}
+		return context.WithValue(ctx, captureTraceKey{}, trace)
+	}
+	return ctx
+}
+
+func (l *CaptureLogger) record(ctx context.Context, level string, msg string, fields map[string]any) {
+	entry := Entry{Time: time.Now(), Level: level, Message: msg, Fields: fields, Logger: l.name}
+	if ctx != nil {
+		if trace, ok := ctx.Value(captureTraceKey{}).(dio.TraceContext); ok {
+			entry.TraceID = trace.TraceID
+		}
+	}
+	l.store.mu.Lock()
+	l.store.entries = append(l.store.entries, entry)
+	l.store.mu.Unlock()
+}
+
+func kvFields(keyAndValues []any) map[string]any {
+	fields := make(map[string]any, len(keyAndValues)/2)
+	for i := 0; i < len(keyAndValues); i += 2 {
+		if i+1 == len(keyAndValues) {
+			fields["!BADKEY"] = keyAndValues[i]
+			break
+		}
+		fields[fmt.Sprint(keyAndValues[i])] = keyAndValues[i+1]
+	}
+	return fields
+}
+
+func mapFields(keyAndValues []map[string]any) map[string]any {
+	fields := map[string]any{}
+	for _, m := range keyAndValues {
+		for key, value := range m {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+func (l *CaptureLogger) Debug(ctx context.Context, msg string, keyAndValues ...any) {
+	l.record(ctx, LevelDebug, msg, kvFields(keyAndValues))
+}
+
+func (l *CaptureLogger) Info(ctx context.Context, msg string, keyAndValues ...any) {
+	l.record(ctx, LevelInfo, msg, kvFields(keyAndValues))
+}
+
+func (l *CaptureLogger) Warn(ctx context.Context, msg string, keyAndValues ...any) {
+	l.record(ctx, LevelWarn, msg, kvFields(keyAndValues))
+}
+
+func (l *CaptureLogger) Error(ctx context.Context, msg string, keyAndValues ...any) {
+	l.record(ctx, LevelError, msg, kvFields(keyAndValues))
+}
+
+func (l *CaptureLogger) Debugw(ctx context.Context, msg string, keyAndValues ...map[string]any) {
+	l.record(ctx, LevelDebug, msg, mapFields(keyAndValues))
+}
+
+func (l *CaptureLogger) Infow(ctx context.Context, msg string, keyAndValues ...map[string]any) {
+	l.record(ctx, LevelInfo, msg, mapFields(keyAndValues))
+}
+
+func (l *CaptureLogger) Warnw(ctx context.Context, msg string, keyAndValues ...map[string]any) {
+	l.record(ctx, LevelWarn, msg, mapFields(keyAndValues))
+}
+
+func (l *CaptureLogger) Errorw(ctx context.Context, msg string, keyAndValues ...map[string]any) {
+	l.record(ctx, LevelError, msg, mapFields(keyAndValues))
+}
+
+// Entries 返回捕获的全部日志（按输出顺序，含 Named 派生 logger 的日志）。
+func (l *CaptureLogger) Entries() []Entry {
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+	return append([]Entry(nil), l.store.entries...)
+}
+
+// Filter 返回满足 match 的日志。
+func (l *CaptureLogger) Filter(match func(Entry) bool) (entries []Entry) {
+	for _, entry := range l.Entries() {
+		if match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// ByLevel 返回指定级别的日志。
+func (l *CaptureLogger) ByLevel(level string) []Entry {
+	return l.Filter(func(e Entry) bool { return e.Level == level })
+}
+
+// ByLogger 返回指定 Named logger（含其子 logger，如 db 匹配 db.pool）的日志。
+func (l *CaptureLogger) ByLogger(name string) []Entry {
+	return l.Filter(func(e Entry) bool { return e.Logger == name || strings.HasPrefix(e.Logger, name+".") })
+}
+
+// ByTrace 返回指定 trace id 的日志。
+func (l *CaptureLogger) ByTrace(traceID string) []Entry {
+	return l.Filter(func(e Entry) bool { return e.TraceID == traceID })
+}
+
+// Find 返回第一条级别与消息匹配、且包含 keyAndValues 字段的日志（level 为空时不限级别，字段值按 reflect.DeepEqual 比较）。
+func (l *CaptureLogger) Find(level string, msg string, keyAndValues ...any) (Entry, bool) {
+	want := kvFields(keyAndValues)
+	for _, entry := range l.Entries() {
+		if (level == "" || entry.Level == level) && entry.Message == msg && hasFields(entry, want) {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+func hasFields(entry Entry, want map[string]any) bool {
+	for key, value := range want {
+		if got, ok := entry.Fields[key]; !ok || !reflect.DeepEqual(got, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Reset 清空捕获的日志。
+func (l *CaptureLogger) Reset() {
+	l.store.mu.Lock()
+	l.store.entries = nil
+	l.store.mu.Unlock()
+}
+
+// AssertLogged 断言输出过级别与消息匹配、且包含 keyAndValues 字段的日志，返回该日志；不存在时 t.Errorf 并列出全部日志。
+func (l *CaptureLogger) AssertLogged(t testing.TB, level string, msg string, keyAndValues ...any) Entry {
+	t.Helper()
+	entry, ok := l.Find(level, msg, keyAndValues...)
+	if !ok {
+		t.Errorf("diotest: want log %s %q %v, got:\n%s", level, msg, kvFields(keyAndValues), l.dump())
+	}
+	return entry
+}
+
+// AssertNotLogged 断言没有输出过级别与消息匹配的日志（level 为空时不限级别）。
+func (l *CaptureLogger) AssertNotLogged(t testing.TB, level string, msg string) {
+	t.Helper()
+	if entry, ok := l.Find(level, msg); ok {
+		t.Errorf("diotest: unexpected log %s", entry)
+	}
+}
+
+// AssertNoErrors 断言没有输出过 ERROR 级别的日志。
+func (l *CaptureLogger) AssertNoErrors(t testing.TB) {
+	t.Helper()
+	if errors := l.ByLevel(LevelError); len(errors) > 0 {
+		t.Errorf("diotest: want no error logs, got:\n%s", dumpEntries(errors))
+	}
+}
+
+func (l *CaptureLogger) dump() string {
+	return dumpEntries(l.Entries())
+}
+
+func dumpEntries(entries []Entry) string {
+	if len(entries) == 0 {
+		return "  (no logs)"
+	}
+	lines := make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = "  " + entry.String()
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,175 @@
+package dio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cheivin/di"
+	"github.com/cheivin/dio-core"
+)
+
+// FailureAnalysis 启动失败分析结果：可读的问题描述与建议的修正动作。
+type FailureAnalysis struct {
+	Description string // 问题描述
+	Action      string // 建议动作
+	Cause       error  // 原始错误
+}
+
+func (a FailureAnalysis) String() string {
+	return "\n***************************\n" +
+		"APPLICATION FAILED TO START\n" +
+		"***************************\n\n" +
+		"Description:\n\n" + a.Description + "\n\n" +
+		"Action:\n\n" + a.Action + "\n"
+}
+
+// FailureAnalyzer 启动失败分析器：将已知的启动错误转换为问题描述与建议动作。
+// 无法识别的错误返回 nil，交给下一个分析器处理。
+type FailureAnalyzer interface {
+	Analyze(cause error) *FailureAnalysis
+}
+
+// FailureAnalyzerFunc 函数形式的 FailureAnalyzer。
+type FailureAnalyzerFunc func(cause error) *FailureAnalysis
+
+func (f FailureAnalyzerFunc) Analyze(cause error) *FailureAnalysis {
+	return f(cause)
+}
+
+// defaultFailureAnalyzers 内置失败分析器，排在自定义分析器之后。
+var defaultFailureAnalyzers = []FailureAnalyzer{
+	FailureAnalyzerFunc(analyzeMissingProperty),
+	FailureAnalyzerFunc(analyzeAlreadyRun),
+	FailureAnalyzerFunc(analyzeCircularDependency),
+	FailureAnalyzerFunc(analyzeAwareInjection),
+}
+
+// AddFailureAnalyzer 注册启动失败分析器。Run 启动失败时按注册顺序依次分析，
+// 自定义分析器优先于内置分析器，第一个返回非 nil 的结果会被输出（日志组件未创建时输出到 stderr）。
+// 必须在 Run 前调用。
+func (d *dioContainer) AddFailureAnalyzer(analyzers ...FailureAnalyzer) core.Dio {
+	d.mu.Lock()
+	d.failureAnalyzers = append(d.failureAnalyzers, analyzers...)
+	d.mu.Unlock()
+	return d
+}
+
+// FailureCause 返回最近一次 Run 启动失败的原因（状态为 Failed 时有值），重新 Run 时清空。
+// panic 值不是 error 时会被包装为 error。
+func (d *dioContainer) FailureCause() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.failureCause
+}
+
+// analyzeFailure 依次执行自定义与内置分析器，返回第一个识别出的结果（均无法识别返回 nil）。
+func (d *dioContainer) analyzeFailure(cause error) *FailureAnalysis {
+	d.mu.Lock()
+	analyzers := append(append([]FailureAnalyzer(nil), d.failureAnalyzers...), defaultFailureAnalyzers...)
+	d.mu.Unlock()
+	for _, analyzer := range analyzers {
+		if analysis := safeAnalyze(analyzer, cause); analysis != nil {
+			if analysis.Cause == nil {
+				analysis.Cause = cause
+			}
+			return analysis
+		}
+	}
+	return nil
+}
+
+// safeAnalyze 执行单个分析器，分析器自身 panic 时视为无法识别（不能掩盖原始错误）。
+func safeAnalyze(analyzer FailureAnalyzer, cause error) (analysis *FailureAnalysis) {
+	defer func() {
+		if r := recover(); r != nil {
+			analysis = nil
+		}
+	}()
+	return analyzer.Analyze(cause)
+}
+
+// reportFailure 输出启动失败分析结果：日志组件可用时以 ERROR 级别记录，否则输出到 stderr。
+// 无法识别的错误不输出（由 panic 原样抛出）。
+func (d *dioContainer) reportFailure(cause error) {
+	analysis := d.analyzeFailure(cause)
+	if analysis == nil {
+		return
+	}
+	if d.log != nil {
+		d.log.Error(context.Background(), analysis.String())
+	} else {
+		_, _ = fmt.Fprintln(os.Stderr, analysis.String())
+	}
+}
+
+// panicError 将 recover 得到的 panic 值转换为 error。
+func panicError(r any) error {
+	if err, ok := r.(error); ok {
+		return err
+	}
+	return fmt.Errorf("%v", r)
+}
+
+func analyzeMissingProperty(cause error) *FailureAnalysis {
+	if !errors.Is(cause, ErrMissingProperty) {
+		return nil
+	}
+	keys := strings.TrimPrefix(cause.Error(), ErrMissingProperty.Error()+": ")
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("Required properties are missing: %s", keys),
+		Action:      "Set the missing properties in the config file (LoadConfig/LoadConfigDir), environment variables (AutoMigrateEnv) or SetProperty before Run, or remove them from RequireProperties.",
+	}
+}
+
+func analyzeAlreadyRun(cause error) *FailureAnalysis {
+	if !errors.Is(cause, ErrAlreadyRun) && !errors.Is(cause, di.ErrLoaded) {
+		return nil
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("The container has already been run: %v", cause),
+		Action:      "Call Run only once per container, and register beans/logger before Run. A container that failed after beans were loaded cannot be retried; create a new container instead.",
+	}
+}
+
+// 内置分析器的错误信息匹配：按整词匹配，避免 "software"、"unaware" 之类的误判。
+var (
+	circularWordPattern   = regexp.MustCompile(`(?i)\bcircular\b`)
+	circularPhrasePattern = regexp.MustCompile(`(?i)\bcircular (dependency|dependencies|reference)\b`)
+	awareWordPattern      = regexp.MustCompile(`(?i)\baware\b`)
+	notFoundPattern       = regexp.MustCompile(`(?i)\bnot found\b`)
+	awarePhrasePattern    = regexp.MustCompile(`(?i)\baware (field|tag|injection)\b`)
+)
+
+// isDIError 返回是否为 di 的 bean/定义错误。
+func isDIError(cause error) bool {
+	return errors.Is(cause, di.ErrBean) || errors.Is(cause, di.ErrDefinition)
+}
+
+// analyzeCircularDependency 识别 di 的循环依赖错误；未包装 di 哨兵的错误仅匹配完整的 "circular dependency" 描述。
+func analyzeCircularDependency(cause error) *FailureAnalysis {
+	msg := cause.Error()
+	if !(isDIError(cause) && circularWordPattern.MatchString(msg)) && !circularPhrasePattern.MatchString(msg) {
+		return nil
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("A circular dependency between beans was detected: %v", cause),
+		Action:      "Break the cycle by moving the shared logic into a separate bean, or disable strict checking with WithCircularCheck(false) if the pointer cycle is intended.",
+	}
+}
+
+// analyzeAwareInjection 识别 di 的 aware 注入失败（ErrBean 且提及 aware 或 not found）；未包装 di 哨兵的错误仅匹配 "aware field/tag/injection"。
+func analyzeAwareInjection(cause error) *FailureAnalysis {
+	msg := cause.Error()
+	diMatched := errors.Is(cause, di.ErrBean) && (awareWordPattern.MatchString(msg) || notFoundPattern.MatchString(msg))
+	if !diMatched && !awarePhrasePattern.MatchString(msg) {
+		return nil
+	}
+	return &FailureAnalysis{
+		Description: fmt.Sprintf("A bean required by an aware field could not be resolved: %v", cause),
+		Action:      "Make sure the dependency is registered (Provide/RegisterBean/ProvideFunc) and its condition (ProvideOnProperty/OnProfile) matches the current properties, check the bean name in the aware tag, and that the field type matches the registered bean type.",
+	}
+}
@@ -3,6 +3,8 @@ package dio
 import (
 	"context"
 	"io/fs"
+	"net/http"
+	"os"
 	"sync"
 	"time"
 
@@ -162,6 +164,11 @@ func DescribeBean(beanName string) (desc di.BeanDescription, ok bool) {
 	return container().(*dioContainer).DescribeBean(beanName)
 }
 
+// DescribeBeans 返回所有 bean 的管理视图与依赖关系图（可导出为 JSON/DOT/Mermaid）。
+func DescribeBeans() BeanGraph {
+	return container().(*dioContainer).DescribeBeans()
+}
+
 // GetBeanDependencies 返回 bean 依赖的其他 bean 名称列表。
 func GetBeanDependencies(beanName string) (deps []string, ok bool) {
 	return container().(*dioContainer).GetBeanDependencies(beanName)
@@ -201,16 +208,36 @@ func Run(ctx context.Context) {
 	container().Run(ctx)
 }
 
+// Restart 重置全局容器并重新执行完整生命周期（仅 Stopped/Failed 状态可调用）。
+func Restart(ctx context.Context) {
+	container().(*dioContainer).Restart(ctx)
+}
+
 // SetBanner 设置启动 banner（传空字符串关闭）。
 func SetBanner(banner string) core.Dio {
 	return container().(*dioContainer).SetBanner(banner)
 }
 
+// SetBannerFile 从 fsys 中的 name 文件加载启动 banner 模板。
+func SetBannerFile(fsys fs.FS, name string) core.Dio {
+	return container().(*dioContainer).SetBannerFile(fsys, name)
+}
+
+// SetBannerMode 设置 banner 输出方式（console / log / off）。
+func SetBannerMode(mode string) core.Dio {
+	return container().(*dioContainer).SetBannerMode(mode)
+}
+
 // StartupDuration 返回全局容器启动耗时（未 Run 时返回 0）。
 func StartupDuration() time.Duration {
 	return container().(*dioContainer).StartupDuration()
 }
 
+// StartupReport 返回全局容器最近一次 Run 的启动耗时报告（阶段耗时与逐 bean 耗时）。
+func StartupReport() StartupTiming {
+	return container().(*dioContainer).StartupReport()
+}
+
 // SetProfile 设置应用运行环境（profile），影响配置加载与条件装配。
 func SetProfile(profile string) core.Dio {
 	return container().(*dioContainer).SetProfile(profile)
@@ -246,21 +273,76 @@ func State() AppState {
 	return container().(*dioContainer).State()
 }
 
-// OnStateChange 注册全局容器状态变更回调，状态推进时倒序执行。
+// StateHistory 返回全局容器的状态变更记录（按时间先后）。
+func StateHistory() []StateTransition {
+	return container().(*dioContainer).StateHistory()
+}
+
+// OnStateChange 注册全局容器状态变更回调，状态推进时倒序执行（Run 后注册会先重放已发生的状态）。
 func OnStateChange(fn func(AppState)) core.Dio {
 	return container().(*dioContainer).OnStateChange(fn)
 }
 
+// AddFailureAnalyzer 注册全局容器启动失败分析器（优先于内置分析器）。
+func AddFailureAnalyzer(analyzers ...FailureAnalyzer) core.Dio {
+	return container().(*dioContainer).AddFailureAnalyzer(analyzers...)
+}
+
+// FailureCause 返回全局容器最近一次启动失败的原因（未失败返回 nil）。
+func FailureCause() error {
+	return container().(*dioContainer).FailureCause()
+}
+
+// OnSignal 注册全局容器自定义信号处理器，仅在 Running 状态下串行执行。
+func OnSignal(sig os.Signal, fn func(context.Context)) core.Dio {
+	return container().(*dioContainer).OnSignal(sig, fn)
+}
+
 // Ready 返回全局容器是否就绪。
 func Ready() bool {
 	return container().(*dioContainer).Ready()
 }
 
-// Health 健康检查：聚合容器内所有 core.HealthChecker bean 的检查结果。
+// CheckHealth 执行健康检查并返回结构化报告（整体状态与各检查器的状态/耗时/错误/详情）。
+func CheckHealth(ctx context.Context) HealthReport {
+	return container().(*dioContainer).CheckHealth(ctx)
+}
+
+// HealthGroup 只执行指定分组（liveness/readiness/startup/自定义）的健康检查并返回报告。
+func HealthGroup(ctx context.Context, group string) HealthReport {
+	return container().(*dioContainer).HealthGroup(ctx, group)
+}
+
+// Info 返回全局容器的应用信息（app.* 配置、构建信息与 InfoContributor 贡献的内容）。
+func Info() map[string]any {
+	return container().(*dioContainer).Info()
+}
+
+// Health 健康检查：聚合容器内所有健康检查器的结果（CheckHealth 的简化形式）。
 func Health(ctx context.Context) error {
 	return container().(*dioContainer).Health(ctx)
 }
 
+// SetLogLevel 运行期调整日志级别（name 为空或 "root" 为全局级别，否则为 Named 前缀级别；level 为空时移除前缀级别）。
+func SetLogLevel(name string, level string) error {
+	return container().(*dioContainer).SetLogLevel(name, level)
+}
+
+// LogLevels 返回当前日志组件的级别表（"root" 为全局级别）。
+func LogLevels() map[string]string {
+	return container().(*dioContainer).LogLevels()
+}
+
+// AddContextFieldExtractor 注册日志上下文字段提取器（每条日志自动附加从 ctx 提取的字段）。
+func AddContextFieldExtractor(extractors ...ContextFieldExtractor) core.Dio {
+	return container().(*dioContainer).AddContextFieldExtractor(extractors...)
+}
+
+// ManagementHandler 返回管理端点（/health、/ready、/live、/info、/beans、/env 等）的 http.Handler，可挂载到已有路由。
+func ManagementHandler() http.Handler {
+	return container().(*dioContainer).ManagementHandler()
+}
+
 func Use(plugins ...core.PluginConfig) core.Dio {
 	return container().Use(plugins...)
 }
@@ -277,3 +359,8 @@ func LoadConfig(configs fs.FS, filename string) core.Dio {
 func LoadConfigDir(configs fs.FS, dir string) core.Dio {
 	return container().(*dioContainer).LoadConfigDir(configs, dir)
 }
+
+// ReloadConfig 按原加载顺序重新读取已加载过的配置文件（只覆盖/新增配置项）。
+func ReloadConfig() error {
+	return container().(*dioContainer).ReloadConfig()
+}
@@ -0,0 +1,333 @@
+package dio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cheivin/dio-core"
+)
+
+// 健康检查默认值：可通过 health.timeout / health.concurrency 配置覆盖。
+const (
+	// healthCheckTimeout 单个健康检查的默认超时时间。
+	// 检查器可通过 HealthTimeout 声明自己的超时；传给 Health 的 ctx deadline 会进一步收紧（整体时限），但不能放宽。
+	healthCheckTimeout = 5 * time.Second
+	// healthCheckConcurrency 健康检查的默认并发数（<=0 表示不限制）。
+	healthCheckConcurrency = 4
+)
+
+// HealthTimeout 声明自身超时时间的健康检查器（返回值 <=0 时使用 health.timeout 默认超时）。
+type HealthTimeout interface {
+	HealthTimeout() time.Duration
+}
+
+// HealthStatus 健康状态。
+type HealthStatus string
+
+// 健康状态：
+//   - StatusUp：健康
+//   - StatusDown：不健康（整体状态为 DOWN 时 Health 返回错误）
+//   - StatusDegraded：降级（可用但部分能力受损，不视为失败）
+//   - StatusUnknown：状态未知（不影响整体状态）
+const (
+	StatusUp       HealthStatus = "UP"
+	StatusDown     HealthStatus = "DOWN"
+	StatusDegraded HealthStatus = "DEGRADED"
+	StatusUnknown  HealthStatus = "UNKNOWN"
+)
+
+// severity 状态严重程度，聚合时取最严重者：DOWN > DEGRADED > UP > UNKNOWN。
+func (s HealthStatus) severity() int {
+	switch s {
+	case StatusDown:
+		return 3
+	case StatusDegraded:
+		return 2
+	case StatusUp:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// HealthDetailer 提供详细结果的健康检查器：除错误外还可返回状态与详情（如连接池用量、延迟）。
+// bean 同时实现 core.HealthChecker 时优先使用 HealthDetails。
+// status 为空时按 err 推断：err 非 nil 为 DOWN，否则为 UP；status 为 DOWN 且 err 为 nil 时同样视为失败。
+type HealthDetailer interface {
+	HealthDetails(ctx context.Context) (status HealthStatus, details map[string]any, err error)
+}
+
+// ComponentHealth 单个健康检查器的结果。
+type ComponentHealth struct {
+	Name     string         `json:"name"`              // bean 名称
+	Status   HealthStatus   `json:"status"`            // 检查状态
+	Time     time.Time      `json:"time"`              // 检查开始时间（缓存结果据此计算时效）
+	Duration time.Duration  `json:"duration"`          // 检查耗时
+	Error    error          `json:"error,omitempty"`   // 检查错误（DOWN 时非空）
+	Details  map[string]any `json:"details,omitempty"` // 检查详情（HealthDetailer 提供）
+}
+
+// MarshalJSON 以可读形式输出耗时（如 "1.2ms"）与错误信息。
+func (c ComponentHealth) MarshalJSON() ([]byte, error) {
+	type component ComponentHealth
+	out := struct {
+		component
+		Duration string `json:"duration"`
+		Error    string `json:"error,omitempty"`
+	}{component: component(c), Duration: c.Duration.String()}
+	if c.Error != nil {
+		out.Error = c.Error.Error()
+	}
+	return json.Marshal(out)
+}
+
+// HealthReport 健康检查报告：整体状态与各检查器结果（按检查器注册顺序）。
+type HealthReport struct {
+	Group      string            `json:"group,omitempty"`      // 健康分组（CheckHealth 为空，表示全部检查器）
+	Status     HealthStatus      `json:"status"`               // 整体状态（各检查器中最严重者，无检查器时为 UP）
+	State      AppState          `json:"state"`                // 检查时的容器状态（非 Running 时整体为 DOWN，liveness/startup 分组除外）
+	Time       time.Time         `json:"time"`                 // 检查开始时间（缓存结果为最早一个检查器的检查时间）
+	Duration   time.Duration     `json:"-"`                    // 检查总耗时
+	Cached     bool              `json:"cached,omitempty"`     // 是否为后台检查的缓存结果
+	Components []ComponentHealth `json:"components,omitempty"` // 各检查器结果
+}
+
+// Age 返回报告的时效（距检查开始的时间），缓存结果据此判断新鲜程度。
+func (r HealthReport) Age() time.Duration {
+	return time.Since(r.Time)
+}
+
+// Err 将报告转换为错误：聚合所有 DOWN 检查器的错误（errors.Join，可用 errors.Is 判断）；
+// 整体为 DOWN 但没有检查器失败时（容器状态不满足）返回 ErrNotReady；非 DOWN 返回 nil。
+func (r HealthReport) Err() error {
+	var errs []error
+	for _, c := range r.Components {
+		if c.Status != StatusDown {
+			continue
+		}
+		if c.Error != nil {
+			errs = append(errs, fmt.Errorf("health check failed for %s: %w", c.Name, c.Error))
+		} else {
+			errs = append(errs, fmt.Errorf("health check failed for %s: status %s", c.Name, c.Status))
+		}
+	}
+	if len(errs) == 0 && r.Status == StatusDown {
+		return ErrNotReady
+	}
+	return errors.Join(errs...)
+}
+
+// aggregate 按各检查器结果计算整体状态（取最严重者）；缓存结果的 Time 取最早一个检查器的检查时间。
+func (r *HealthReport) aggregate() {
+	for _, component := range r.Components {
+		if component.Status.severity() > r.Status.severity() {
+			r.Status = component.Status
+		}
+		if r.Cached && component.Time.Before(r.Time) {
+			r.Time = component.Time
+		}
+	}
+}
+
+// CheckHealth 执行健康检查并返回结构化报告：聚合容器内所有实现 core.HealthChecker 或 HealthDetailer 的 bean。
+// 开启后台检查（health.background.interval）时直接返回缓存结果（Cached=true），缓存过期才同步检查。
+// 检查并发执行（并发数由 health.concurrency 配置，默认 4，<=0 不限制），结果按检查器注册顺序排列。
+// 每个检查独立超时（HealthTimeout 声明，否则为 health.timeout，默认 5s），ctx 若带 deadline 会进一步收紧；
+// 超时或 ctx 结束时不再等待未完成的检查（记为 DOWN），忽略 ctx 的检查器会在后台执行至返回（期间占用并发槽位，且不会被重复启动）。
+// 容器未就绪（Ready=false）时不执行检查，整体状态为 DOWN。
+func (d *dioContainer) CheckHealth(ctx context.Context) HealthReport {
+	start := time.Now()
+	report := HealthReport{Status: StatusUp, State: d.State(), Time: start}
+	if report.State != Running {
+		report.Status = StatusDown
+		return report
+	}
+	report.Components, report.Cached = d.evaluateHealth(ctx, d.healthCheckers())
+	report.aggregate()
+	report.Duration = time.Since(start)
+	return report
+}
+
+// checkComponents 并发执行检查器（受 health.concurrency 限制），结果与 checkers 顺序一致。
+// 并发槽位在所有调用（含后台检查）间共享，检查器 goroutine 真正退出后才释放：忽略 ctx 的检查器超时后仍占用槽位，
+// 同一检查器上一次执行未结束时不重复启动，而是等待该次执行的结果。
+// 排队等待槽位同样计入检查超时，超时或 ctx 结束时仍在排队的检查直接记为 DOWN。
+func (d *dioContainer) checkComponents(ctx context.Context, checkers []healthChecker) []ComponentHealth {
+	components := make([]ComponentHealth, len(checkers))
+	if len(checkers) == 0 {
+		return components
+	}
+	defaultTimeout := d.durationProperty("health.timeout", healthCheckTimeout)
+	concurrency := healthCheckConcurrency
+	if v := d.GetPropertyString("health.concurrency"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			concurrency = n
+		}
+	}
+	slots := d.healthRuns.slotsFor(concurrency)
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Add(1)
+		go func(i int, checker healthChecker) {
+			defer wg.Done()
+			timeout := defaultTimeout
+			if t, ok := checker.bean.(HealthTimeout); ok && t.HealthTimeout() > 0 {
+				timeout = t.HealthTimeout()
+			}
+			components[i] = d.checkComponent(ctx, checker, slots, timeout)
+		}(i, checker)
+	}
+	wg.Wait()
+	return components
+}
+
+// Health 健康检查：聚合容器内所有健康检查器的结果，是 CheckHealth 的简化形式（并发与超时语义相同）。
+// 任一检查器 DOWN 即返回聚合错误（errors.Join，可用 errors.Is 判断）；DEGRADED/UNKNOWN 不视为失败。
+// 容器未就绪（Ready=false）时直接返回 ErrNotReady，避免在启动未完成时误报健康。
+func (d *dioContainer) Health(ctx context.Context) error {
+	return d.CheckHealth(ctx).Err()
+}
+
+// healthChecker 容器内的健康检查器 bean（实现 core.HealthChecker 或 HealthDetailer）。
+type healthChecker struct {
+	name string
+	bean any
+}
+
+// healthCheckers 返回容器内所有健康检查器（按注册顺序，同一 bean 只出现一次）。
+func (d *dioContainer) healthCheckers() (checkers []healthChecker) {
+	seen := map[string]bool{}
+	beans := append(d.di.GetByTypeAll((*core.HealthChecker)(nil)), d.di.GetByTypeAll((*HealthDetailer)(nil))...)
+	for _, checkerBean := range beans {
+		// 容器自身注册为 bean 且实现 HealthChecker（Health 即聚合入口），跳过以避免无限递归
+		if checkerBean.Bean == d || seen[checkerBean.Name] {
+			continue
+		}
+		seen[checkerBean.Name] = true
+		checkers = append(checkers, healthChecker{name: checkerBean.Name, bean: checkerBean.Bean})
+	}
+	return
+}
+
+// checkComponent 执行单个检查器（独立超时），超时即返回 DOWN 而不等待检查器结束。
+func (d *dioContainer) checkComponent(ctx context.Context, checker healthChecker, slots chan struct{}, timeout time.Duration) ComponentHealth {
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	var component ComponentHealth
+	if run, err := d.healthRuns.start(checkCtx, checker, slots); err != nil {
+		component = ComponentHealth{Name: checker.name, Status: StatusDown, Error: err}
+	} else {
+		select {
+		case <-run.done:
+			component = run.component
+		case <-checkCtx.Done():
+			component = ComponentHealth{Name: checker.name, Status: StatusDown, Error: checkCtx.Err()}
+		}
+	}
+	component.Time = start
+	component.Duration = time.Since(start)
+	return component
+}
+
+// healthRunner 跨检查调用共享的执行状态：并发槽位与进行中的检查。
+type healthRunner struct {
+	mu       sync.Mutex
+	slots    chan struct{}         // 并发槽位（health.concurrency <=0 时为 nil，不限制）
+	inflight map[string]*healthRun // 进行中的检查（按检查器名称）
+}
+
+// healthRun 一次检查器执行，done 关闭后 component 可读。
+type healthRun struct {
+	done      chan struct{}
+	component ComponentHealth
+}
+
+// slotsFor 返回容量为 concurrency 的并发槽位，并发数配置变化时新建（已占用旧槽位的执行退出时归还到旧槽位）。
+func (r *healthRunner) slotsFor(concurrency int) chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if concurrency <= 0 {
+		r.slots = nil
+	} else if r.slots == nil || cap(r.slots) != concurrency {
+		r.slots = make(chan struct{}, concurrency)
+	}
+	return r.slots
+}
+
+// start 返回检查器的执行：上一次执行未结束时直接复用，否则占用槽位后在新 goroutine 中执行，
+// 槽位在检查器返回后才释放。ctx 结束前未获得槽位时返回 ctx 的错误。
+func (r *healthRunner) start(ctx context.Context, checker healthChecker, slots chan struct{}) (*healthRun, error) {
+	if run := r.running(checker.name); run != nil {
+		return run, nil
+	}
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	r.mu.Lock()
+	if run := r.inflight[checker.name]; run != nil {
+		// 等待槽位期间其他调用已启动该检查器
+		r.mu.Unlock()
+		if slots != nil {
+			<-slots
+		}
+		return run, nil
+	}
+	run := &healthRun{done: make(chan struct{})}
+	if r.inflight == nil {
+		r.inflight = map[string]*healthRun{}
+	}
+	r.inflight[checker.name] = run
+	r.mu.Unlock()
+	go func() {
+		run.component = runHealthChecker(ctx, checker.name, checker.bean)
+		r.mu.Lock()
+		delete(r.inflight, checker.name)
+		r.mu.Unlock()
+		if slots != nil {
+			<-slots
+		}
+		close(run.done)
+	}()
+	return run, nil
+}
+
+// running 返回检查器进行中的执行（没有时为 nil）。
+func (r *healthRunner) running(name string) *healthRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.inflight[name]
+}
+
+// runHealthChecker 调用检查器并归一化结果，检查器 panic 视为 DOWN。
+func runHealthChecker(ctx context.Context, name string, checker any) (component ComponentHealth) {
+	component.Name = name
+	defer func() {
+		if r := recover(); r != nil {
+			component.Status = StatusDown
+			component.Error = fmt.Errorf("health check panic: %v", r)
+		}
+	}()
+	if detailer, ok := checker.(HealthDetailer); ok {
+		component.Status, component.Details, component.Error = detailer.HealthDetails(ctx)
+	} else {
+		component.Error = checker.(core.HealthChecker).Health(ctx)
+	}
+	if component.Status == "" {
+		if component.Error != nil {
+			component.Status = StatusDown
+		} else {
+			component.Status = StatusUp
+		}
+	}
+	return
+}
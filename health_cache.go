@@ -0,0 +1,123 @@
+package dio
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// HealthInterval 声明后台检查最小间隔的检查器：后台轮询时距上次检查不足该间隔则沿用上次结果。
+// 返回值 <=0 时每轮都检查。仅在开启后台检查（health.background.interval）时生效。
+type HealthInterval interface {
+	HealthInterval() time.Duration
+}
+
+// healthCache 后台健康检查的结果缓存（按检查器名称）。
+type healthCache struct {
+	mu         sync.Mutex
+	components map[string]ComponentHealth
+	maxAge     time.Duration // 缓存结果的最长时效，超过则同步检查
+}
+
+// get 按顺序取出检查器的缓存结果；任一检查器缺失或超过时效时返回 ok=false。
+func (c *healthCache) get(checkers []healthChecker) (components []ComponentHealth, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	components = make([]ComponentHealth, 0, len(checkers))
+	for _, checker := range checkers {
+		component, found := c.components[checker.name]
+		if !found || (c.maxAge > 0 && time.Since(component.Time) > c.maxAge) {
+			return nil, false
+		}
+		components = append(components, component)
+	}
+	return components, true
+}
+
+func (c *healthCache) put(components []ComponentHealth) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, component := range components {
+		c.components[component.Name] = component
+	}
+}
+
+// due 返回本轮需要检查的检查器：从未检查过，或距上次检查已超过其最小间隔（HealthInterval）。
+func (c *healthCache) due(checkers []healthChecker) (due []healthChecker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, checker := range checkers {
+		component, found := c.components[checker.name]
+		if found {
+			if i, ok := checker.bean.(HealthInterval); ok && time.Since(component.Time) < i.HealthInterval() {
+				continue
+			}
+		}
+		due = append(due, checker)
+	}
+	return
+}
+
+// evaluateHealth 执行检查器并返回结果：开启后台检查且缓存有效时直接返回缓存（cached=true），
+// 否则同步检查（结果同时写入缓存）。
+func (d *dioContainer) evaluateHealth(ctx context.Context, checkers []healthChecker) (components []ComponentHealth, cached bool) {
+	d.mu.Lock()
+	cache := d.healthCache
+	d.mu.Unlock()
+	if cache != nil {
+		if components, ok := cache.get(checkers); ok {
+			return components, true
+		}
+	}
+	components = d.checkComponents(ctx, checkers)
+	if cache != nil {
+		cache.put(components)
+	}
+	return components, false
+}
+
+// serveHealth 开启后台健康检查：进入 Running 后按 health.background.interval 轮询全部检查器并缓存结果，
+// 探针读取缓存而不直接触发检查。缓存超过 health.background.max-age（默认 3 倍间隔）视为过期，读取时同步检查。
+// 返回的 stop 函数停止轮询并等待进行中的一轮检查结束（ctx 结束后、di.Serve 销毁 bean 之前调用）。
+// 未配置间隔（或 <=0）时不开启。
+func (d *dioContainer) serveHealth(ctx context.Context) (stop func()) {
+	interval := d.durationProperty("health.background.interval", 0)
+	if interval <= 0 {
+		return func() {}
+	}
+	cache := &healthCache{
+		components: map[string]ComponentHealth{},
+		maxAge:     d.durationProperty("health.background.max-age", 3*interval),
+	}
+	d.mu.Lock()
+	d.healthCache = cache
+	d.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if d.State() != Running {
+				return
+			}
+			if due := cache.due(d.healthCheckers()); len(due) > 0 {
+				cache.put(d.checkComponents(ctx, due))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+		d.mu.Lock()
+		d.healthCache = nil
+		d.mu.Unlock()
+	}
+}
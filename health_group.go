@@ -0,0 +1,119 @@
+package dio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 内置健康分组，对应 Kubernetes 的三类探针。
+const (
+	// HealthGroupLiveness 存活分组：默认只看应用状态（Starting/Running/Stopping 为 UP），不执行依赖检查，
+	// 避免依赖故障导致 Pod 被重启；显式加入的检查器在 Running 时额外执行。
+	HealthGroupLiveness = "liveness"
+	// HealthGroupReadiness 就绪分组：要求 Running；无检查器显式加入时执行全部检查器（与 Health 一致）。
+	HealthGroupReadiness = "readiness"
+	// HealthGroupStartup 启动分组：进入 Running 后为 UP（之后的 Stopping/Stopped 仍视为已启动，Failed 为 DOWN）；
+	// 显式加入的检查器在 Running 时额外执行。
+	HealthGroupStartup = "startup"
+)
+
+// HealthGrouper 声明所属健康分组的检查器（可同时属于多个分组，如 "readiness"、自定义分组）。
+// 也可通过配置 health.groups.{group} 按 bean 名称指定成员（逗号分隔字符串或列表），两者取并集。
+type HealthGrouper interface {
+	HealthGroups() []string
+}
+
+// HealthGroup 只执行指定分组的健康检查并返回报告（并发与超时语义同 CheckHealth）。
+// 内置分组 liveness/readiness/startup 的默认行为见对应常量；
+// 自定义分组要求 Running，且没有任何成员时整体状态为 UNKNOWN（不视为失败）。
+func (d *dioContainer) HealthGroup(ctx context.Context, group string) HealthReport {
+	start := time.Now()
+	report := HealthReport{Group: group, Status: StatusUp, State: d.State(), Time: start}
+	defer func() {
+		report.Duration = time.Since(start)
+	}()
+	var members []healthChecker
+	if report.State == Running {
+		members = d.healthGroupMembers(group)
+	}
+	switch group {
+	case HealthGroupLiveness:
+		if report.State != Starting && report.State != Running && report.State != Stopping {
+			report.Status = StatusDown
+			return report
+		}
+	case HealthGroupStartup:
+		if report.State < Running || report.State == Failed {
+			report.Status = StatusDown
+			return report
+		}
+	case HealthGroupReadiness:
+		if report.State != Running {
+			report.Status = StatusDown
+			return report
+		}
+		if len(members) == 0 {
+			members = d.healthCheckers()
+		}
+	default:
+		if report.State != Running {
+			report.Status = StatusDown
+			return report
+		}
+		if len(members) == 0 {
+			report.Status = StatusUnknown
+			return report
+		}
+	}
+	report.Components, report.Cached = d.evaluateHealth(ctx, members)
+	report.aggregate()
+	return report
+}
+
+// healthGroupMembers 返回分组的成员检查器：实现 HealthGrouper 且声明了该分组，或在 health.groups.{group} 中按名称配置。
+func (d *dioContainer) healthGroupMembers(group string) (members []healthChecker) {
+	configured := map[string]bool{}
+	for _, name := range d.propertyList("health.groups." + group) {
+		configured[name] = true
+	}
+	for _, checker := range d.healthCheckers() {
+		if configured[checker.name] {
+			members = append(members, checker)
+			continue
+		}
+		if grouper, ok := checker.bean.(HealthGrouper); ok {
+			for _, g := range grouper.HealthGroups() {
+				if g == group {
+					members = append(members, checker)
+					break
+				}
+			}
+		}
+	}
+	return
+}
+
+// propertyList 读取列表型配置：支持 yaml 列表与逗号分隔字符串，忽略空项。
+func (d *dioContainer) propertyList(key string) (list []string) {
+	var items []string
+	switch val := d.di.Property().Get(key).(type) {
+	case nil:
+		return nil
+	case []any:
+		for _, item := range val {
+			items = append(items, fmt.Sprintf("%v", item))
+		}
+	case []string:
+		items = val
+	default:
+		items = strings.Split(fmt.Sprintf("%v", val), ",")
+	}
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package dio
+
+import (
+	"context"
+	"runtime/debug"
+)
+
+// BuildTime 构建时间，可通过 -ldflags "-X github.com/cheivin/dio.BuildTime=..." 注入；为空时取 vcs 提交时间。
+var BuildTime string
+
+// InfoContributor 向应用信息（Info、管理端点 /info）贡献内容的 bean，
+// 按注册顺序执行，写入的 key 覆盖内置信息（app / build）与先执行者的同名 key。
+type InfoContributor interface {
+	Contribute(info map[string]any)
+}
+
+// BuildInfo 构建信息（来自 debug.ReadBuildInfo），Info 中以 build 输出。
+type BuildInfo struct {
+	Module    string `json:"module,omitempty"`   // 主模块路径
+	Version   string `json:"version,omitempty"`  // 主模块版本（go build 本地构建为 (devel)）
+	Revision  string `json:"revision,omitempty"` // vcs.revision
+	Dirty     bool   `json:"dirty"`              // vcs.modified：构建时工作区有未提交的修改
+	Time      string `json:"time,omitempty"`     // BuildTime，为空时取 vcs.time（提交时间）
+	GoVersion string `json:"goVersion"`
+}
+
+// ReadBuildInfo 读取当前程序的构建信息，未嵌入构建信息（如非模块构建）时 ok=false。
+func ReadBuildInfo() (info BuildInfo, ok bool) {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return BuildInfo{Time: BuildTime}, false
+	}
+	info = BuildInfo{Module: bi.Main.Path, Version: bi.Main.Version, Time: BuildTime, GoVersion: bi.GoVersion}
+	for _, setting := range bi.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.modified":
+			info.Dirty = setting.Value == "true"
+		case "vcs.time":
+			if info.Time == "" {
+				info.Time = setting.Value
+			}
+		}
+	}
+	return info, true
+}
+
+// buildInfoContributor 内置的构建信息贡献者。
+type buildInfoContributor struct{}
+
+func (buildInfoContributor) Contribute(info map[string]any) {
+	if build, ok := ReadBuildInfo(); ok {
+		info["build"] = build
+	}
+}
+
+// Info 返回应用信息：app.* 配置（app，副本且敏感项掩码，规则同 /env）、构建信息（build）与容器内所有 InfoContributor bean 贡献的内容。
+// 容器未就绪（非 Running 状态）时只包含内置信息。
+func (d *dioContainer) Info() map[string]any {
+	info := map[string]any{}
+	if app := d.propertySnapshot("app"); app != nil {
+		info["app"] = maskSensitive("app", app)
+	}
+	buildInfoContributor{}.Contribute(info)
+	if d.Ready() {
+		for _, contributor := range d.di.GetByTypeAll((*InfoContributor)(nil)) {
+			if contributor.Bean == d {
+				continue
+			}
+			contributor.Bean.(InfoContributor).Contribute(info)
+		}
+	}
+	return info
+}
+
+// logInfo 启动完成后输出应用信息。
+func (d *dioContainer) logInfo(ctx context.Context) {
+	d.log.Infow(ctx, "application info", d.Info())
+}
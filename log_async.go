@@ -0,0 +1,295 @@
+package dio
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// 异步日志缓冲区满时的处理策略（log.async.overflow）。
+const (
+	LogOverflowBlock           = "block"             // 阻塞等待缓冲区有空位（默认，不丢日志）
+	LogOverflowDropLowestLevel = "drop-lowest-level" // 丢弃缓冲区中级别最低的最早一条（新日志级别更低时丢弃新日志）
+	LogOverflowDrop            = "drop"              // 丢弃新日志
+)
+
+// 异步日志的默认配置（log.async.buffer-size / log.async.flush-interval）。
+const (
+	defaultAsyncBufferSize    = 8192
+	defaultAsyncFlushInterval = time.Second
+)
+
+// AsyncLogStats 异步日志的缓冲与丢弃统计。
+type AsyncLogStats struct {
+	Buffered       int               `json:"buffered"`       // 缓冲区中待写入的条数
+	Capacity       int               `json:"capacity"`       // 缓冲区容量
+	Overflow       string            `json:"overflow"`       // 缓冲区满时的处理策略
+	Dropped        uint64            `json:"dropped"`        // 累计丢弃条数
+	DroppedByLevel map[string]uint64 `json:"droppedByLevel"` // 按级别的累计丢弃条数
+}
+
+// asyncEntry 已编码、待写入的日志。
+type asyncEntry struct {
+	level zapcore.Level
+	out   zapcore.WriteSyncer
+	buf   *buffer.Buffer
+}
+
+// asyncQueue 异步日志的缓冲队列：调用方编码后入队，后台按 flush 间隔（或缓冲区过半时）批量写出。
+// 各输出（文件/错误文件/控制台）共享同一队列，关闭后退化为同步写入。
+type asyncQueue struct {
+	capacity      int
+	flushInterval time.Duration
+	overflow      string
+
+	mu       sync.Mutex
+	notFull  *sync.Cond
+	entries  []asyncEntry
+	outs     []zapcore.WriteSyncer // 已注册的输出，flush 后逐个 Sync
+	closed   bool
+	wake     chan struct{}
+	done     chan struct{}
+	writeMu  sync.Mutex // 串行化写出，保证日志顺序
+	dropped  [zapcore.FatalLevel - zapcore.DebugLevel + 1]atomic.Uint64
+	stopOnce sync.Once
+}
+
+// newAsyncQueue 按 ZapConfig 创建异步日志队列，未开启 log.async.enabled 时返回 nil。
+func newAsyncQueue(c ZapConfig) (*asyncQueue, error) {
+	if !c.Async {
+		return nil, nil
+	}
+	q := &asyncQueue{
+		capacity:      c.AsyncBufferSize,
+		flushInterval: defaultAsyncFlushInterval,
+		overflow:      strings.ToLower(c.AsyncOverflow),
+		wake:          make(chan struct{}, 1),
+		done:          make(chan struct{}),
+	}
+	if q.capacity <= 0 {
+		q.capacity = defaultAsyncBufferSize
+	}
+	if c.AsyncFlushInterval != "" {
+		interval, err := time.ParseDuration(c.AsyncFlushInterval)
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("dio invalid log async.flush-interval %q", c.AsyncFlushInterval)
+		}
+		q.flushInterval = interval
+	}
+	switch q.overflow {
+	case "":
+		q.overflow = LogOverflowBlock
+	case LogOverflowBlock, LogOverflowDropLowestLevel, LogOverflowDrop:
+	default:
+		return nil, fmt.Errorf("dio invalid log async.overflow %q", c.AsyncOverflow)
+	}
+	q.notFull = sync.NewCond(&q.mu)
+	return q, nil
+}
+
+// core 创建写入本队列的 zapcore.Core，用法同 zapcore.NewCore。
+func (q *asyncQueue) core(enc zapcore.Encoder, out zapcore.WriteSyncer, enab zapcore.LevelEnabler) zapcore.Core {
+	q.mu.Lock()
+	q.outs = append(q.outs, out)
+	q.mu.Unlock()
+	return &asyncCore{LevelEnabler: enab, enc: enc, out: out, queue: q}
+}
+
+// start 启动后台写出。
+func (q *asyncQueue) start() {
+	go q.run()
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.done)
+	ticker := time.NewTicker(q.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-q.wake:
+		}
+		q.drain()
+		q.mu.Lock()
+		closed := q.closed
+		q.mu.Unlock()
+		if closed {
+			return
+		}
+	}
+}
+
+// push 入队已编码的日志；队列已关闭时同步写入。
+func (q *asyncQueue) push(entry asyncEntry) {
+	q.mu.Lock()
+	for !q.closed && len(q.entries) >= q.capacity {
+		if q.overflow == LogOverflowBlock {
+			q.signal()
+			q.notFull.Wait()
+			continue
+		}
+		if q.overflow == LogOverflowDropLowestLevel {
+			if victim := q.lowestLevel(); victim >= 0 && q.entries[victim].level < entry.level {
+				q.drop(q.entries[victim])
+				q.entries = append(q.entries[:victim], q.entries[victim+1:]...)
+				break
+			}
+		}
+		q.mu.Unlock()
+		q.drop(entry)
+		return
+	}
+	if q.closed {
+		q.mu.Unlock()
+		q.writeMu.Lock()
+		q.write([]asyncEntry{entry})
+		q.writeMu.Unlock()
+		return
+	}
+	q.entries = append(q.entries, entry)
+	if len(q.entries) >= q.capacity/2 {
+		q.signal()
+	}
+	q.mu.Unlock()
+}
+
+// lowestLevel 返回缓冲区中级别最低的最早一条的下标，缓冲区为空时返回 -1。
+func (q *asyncQueue) lowestLevel() int {
+	victim := -1
+	for i, e := range q.entries {
+		if victim < 0 || e.level < q.entries[victim].level {
+			victim = i
+		}
+	}
+	return victim
+}
+
+func (q *asyncQueue) drop(entry asyncEntry) {
+	if entry.level >= zapcore.DebugLevel && entry.level <= zapcore.FatalLevel {
+		q.dropped[entry.level-zapcore.DebugLevel].Add(1)
+	}
+	entry.buf.Free()
+}
+
+func (q *asyncQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// drain 写出缓冲区中的全部日志。
+func (q *asyncQueue) drain() {
+	q.writeMu.Lock()
+	defer q.writeMu.Unlock()
+	q.mu.Lock()
+	entries := q.entries
+	q.entries = nil
+	q.notFull.Broadcast()
+	q.mu.Unlock()
+	q.write(entries)
+}
+
+func (q *asyncQueue) write(entries []asyncEntry) {
+	for _, e := range entries {
+		_, _ = e.out.Write(e.buf.Bytes())
+		e.buf.Free()
+	}
+}
+
+// sync 写出缓冲区并 Sync 全部输出。
+func (q *asyncQueue) sync() error {
+	q.drain()
+	q.mu.Lock()
+	outs := q.outs
+	q.mu.Unlock()
+	var errs []error
+	for _, out := range outs {
+		if err := out.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("dio sync async log: %v", errs)
+	}
+	return nil
+}
+
+// close 停止后台写出并写出缓冲区，之后的日志同步写入。可重复调用。
+func (q *asyncQueue) close() error {
+	q.stopOnce.Do(func() {
+		q.mu.Lock()
+		q.closed = true
+		q.notFull.Broadcast()
+		q.mu.Unlock()
+		q.signal()
+		<-q.done
+	})
+	return q.sync()
+}
+
+func (q *asyncQueue) stats() AsyncLogStats {
+	q.mu.Lock()
+	stats := AsyncLogStats{Buffered: len(q.entries), Capacity: q.capacity, Overflow: q.overflow, DroppedByLevel: map[string]uint64{}}
+	q.mu.Unlock()
+	for i := range q.dropped {
+		if n := q.dropped[i].Load(); n > 0 {
+			stats.Dropped += n
+			stats.DroppedByLevel[(zapcore.DebugLevel + zapcore.Level(i)).String()] = n
+		}
+	}
+	return stats
+}
+
+// asyncCore 写入异步队列的 zapcore.Core：调用方 goroutine 内完成编码（字段值在调用时确定），写出交给队列。
+type asyncCore struct {
+	zapcore.LevelEnabler
+	enc   zapcore.Encoder
+	out   zapcore.WriteSyncer
+	queue *asyncQueue
+}
+
+func (c *asyncCore) With(fields []zapcore.Field) zapcore.Core {
+	enc := c.enc.Clone()
+	for i := range fields {
+		fields[i].AddTo(enc)
+	}
+	return &asyncCore{LevelEnabler: c.LevelEnabler, enc: enc, out: c.out, queue: c.queue}
+}
+
+func (c *asyncCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *asyncCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	buf, err := c.enc.EncodeEntry(ent, fields)
+	if err != nil {
+		return err
+	}
+	c.queue.push(asyncEntry{level: ent.Level, out: c.out, buf: buf})
+	// 与 zapcore.NewCore 一致：ERROR 以上（panic/fatal）立即写出，避免进程退出丢失
+	if ent.Level > zapcore.ErrorLevel {
+		return c.queue.sync()
+	}
+	return nil
+}
+
+func (c *asyncCore) Sync() error {
+	return c.queue.sync()
+}
+
+// AsyncStats 返回异步日志的缓冲与丢弃统计，未开启 log.async.enabled 时返回 false。
+func (l *ZapLogger) AsyncStats() (AsyncLogStats, bool) {
+	if l.async == nil {
+		return AsyncLogStats{}, false
+	}
+	return l.async.stats(), true
+}
@@ -0,0 +1,91 @@
+package dio
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cheivin/dio-core"
+)
+
+// ContextFieldExtractor 从 ctx 中提取需要随每条日志输出的字段（如用户 ID、租户）。
+// 返回 key/value 交替的字段列表，ctx 中没有相关值时返回 nil。
+type ContextFieldExtractor interface {
+	ExtractFields(ctx context.Context) []any
+}
+
+// ContextFieldExtractorFunc 函数形式的 ContextFieldExtractor。
+type ContextFieldExtractorFunc func(ctx context.Context) []any
+
+func (f ContextFieldExtractorFunc) ExtractFields(ctx context.Context) []any {
+	return f(ctx)
+}
+
+// contextExtractors 上下文字段提取器列表，在 Named/Skip 派生的 logger 间共享。
+type contextExtractors struct {
+	mu         sync.RWMutex
+	extractors []ContextFieldExtractor
+}
+
+func (e *contextExtractors) set(extractors []ContextFieldExtractor) {
+	e.mu.Lock()
+	e.extractors = append([]ContextFieldExtractor(nil), extractors...)
+	e.mu.Unlock()
+}
+
+// extract 依次执行提取器并合并字段；提取器 panic 时忽略其字段（不能影响日志输出）。
+func (e *contextExtractors) extract(ctx context.Context) (fields []any) {
+	e.mu.RLock()
+	extractors := e.extractors
+	e.mu.RUnlock()
+	for _, extractor := range extractors {
+		fields = append(fields, safeExtract(ctx, extractor)...)
+	}
+	return fields
+}
+
+func safeExtract(ctx context.Context, extractor ContextFieldExtractor) (fields []any) {
+	defer func() {
+		if r := recover(); r != nil {
+			fields = nil
+		}
+	}()
+	return extractor.ExtractFields(ctx)
+}
+
+// contextFieldSetter 支持上下文字段提取器的日志组件（如 ZapLogger）。
+type contextFieldSetter interface {
+	SetContextFieldExtractors(extractors ...ContextFieldExtractor)
+}
+
+// SetContextFieldExtractors 设置上下文字段提取器（替换已有的），每条日志输出时执行并附加其字段。
+// 提取器在 Named/Skip 派生的 logger 间共享。
+func (l *ZapLogger) SetContextFieldExtractors(extractors ...ContextFieldExtractor) {
+	l.extractors.set(extractors)
+}
+
+// AddContextFieldExtractor 注册上下文字段提取器，日志组件支持时（NewZapLogger/WrapZapLogger 创建的 ZapLogger）
+// 每条日志自动附加提取的字段。实现 ContextFieldExtractor 的 bean 在容器加载后自动注册（排在显式注册的之后）。
+// 必须在 Run 前调用。
+func (d *dioContainer) AddContextFieldExtractor(extractors ...ContextFieldExtractor) core.Dio {
+	d.mu.Lock()
+	d.contextExtractors = append(d.contextExtractors, extractors...)
+	d.mu.Unlock()
+	return d
+}
+
+// applyContextExtractors 将显式注册的提取器（withBeans 为 true 时追加 bean 提取器）设置到日志组件。
+func (d *dioContainer) applyContextExtractors(withBeans bool) {
+	setter, ok := d.log.(contextFieldSetter)
+	if !ok {
+		return
+	}
+	d.mu.Lock()
+	extractors := append([]ContextFieldExtractor(nil), d.contextExtractors...)
+	d.mu.Unlock()
+	if withBeans {
+		for _, b := range d.di.GetByTypeAll((*ContextFieldExtractor)(nil)) {
+			extractors = append(extractors, b.Bean.(ContextFieldExtractor))
+		}
+	}
+	setter.SetContextFieldExtractors(extractors...)
+}
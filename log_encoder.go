@@ -0,0 +1,193 @@
+package dio
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// 日志输出格式（log.format / log.file-format / log.error-format / log.std-format）。
+const (
+	LogFormatConsole = "console" // 控制台格式（默认）：制表符分隔，字段以 JSON 附在末尾
+	LogFormatJSON    = "json"    // JSON lines
+	LogFormatLogfmt  = "logfmt"  // logfmt：key=value 空格分隔
+)
+
+// 默认时间格式（console/logfmt），json 格式默认使用 ISO8601。
+const defaultLogTimeFormat = "2006-01-02 15:04:05"
+
+var logBufferPool = buffer.NewPool()
+
+// timeFormatter 解析 log.time-format：预设名（iso8601/rfc3339/rfc3339nano/epoch/epoch-millis/epoch-nanos）或 Go 时间布局。
+func timeFormatter(format string) func(time.Time) string {
+	switch strings.ToLower(format) {
+	case "iso8601":
+		return func(t time.Time) string { return t.Format("2006-01-02T15:04:05.000Z0700") }
+	case "rfc3339":
+		return func(t time.Time) string { return t.Format(time.RFC3339) }
+	case "rfc3339nano":
+		return func(t time.Time) string { return t.Format(time.RFC3339Nano) }
+	case "epoch":
+		return func(t time.Time) string {
+			return strconv.FormatFloat(float64(t.UnixNano())/float64(time.Second), 'f', -1, 64)
+		}
+	case "epoch-millis":
+		return func(t time.Time) string { return strconv.FormatInt(t.UnixMilli(), 10) }
+	case "epoch-nanos":
+		return func(t time.Time) string { return strconv.FormatInt(t.UnixNano(), 10) }
+	default:
+		return func(t time.Time) string { return t.Format(format) }
+	}
+}
+
+// encoderKey 字段 key 配置："-" 表示不输出该字段，空串使用默认值。
+func encoderKey(key string, defaultKey string) string {
+	switch key {
+	case "":
+		return defaultKey
+	case "-":
+		return zapcore.OmitKey
+	default:
+		return key
+	}
+}
+
+// newLogEncoder 按格式构建编码器。color 仅对 console 格式生效（level 着色）。
+func newLogEncoder(format string, color bool, c ZapConfig) (zapcore.Encoder, error) {
+	format = strings.ToLower(format)
+	if format == "" {
+		format = LogFormatConsole
+	}
+	timeFormat := c.TimeFormat
+	if timeFormat == "" {
+		timeFormat = defaultLogTimeFormat
+		if format == LogFormatJSON {
+			timeFormat = "iso8601"
+		}
+	}
+	formatTime := timeFormatter(timeFormat)
+	encodeLevel := zapcore.LevelEncoder(zapcore.CapitalLevelEncoder)
+	if color && format == LogFormatConsole {
+		encodeLevel = zapcore.CapitalColorLevelEncoder
+	}
+	cfg := zapcore.EncoderConfig{
+		TimeKey:       encoderKey(c.TimeKey, "ts"),
+		LevelKey:      encoderKey(c.LevelKey, "level"),
+		NameKey:       encoderKey(c.NameKey, "logger"),
+		CallerKey:     encoderKey(c.CallerKey, "caller"),
+		MessageKey:    encoderKey(c.MessageKey, "msg"),
+		StacktraceKey: encoderKey(c.StacktraceKey, "stacktrace"),
+		LineEnding:    zapcore.DefaultLineEnding,
+		EncodeLevel:   encodeLevel,
+		EncodeTime: func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendString(formatTime(t))
+		},
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+	switch format {
+	case LogFormatConsole:
+		return zapcore.NewConsoleEncoder(cfg), nil
+	case LogFormatJSON:
+		return zapcore.NewJSONEncoder(cfg), nil
+	case LogFormatLogfmt:
+		return &logfmtEncoder{MapObjectEncoder: zapcore.NewMapObjectEncoder(), cfg: cfg, formatTime: formatTime}, nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q, want console/json/logfmt", format)
+	}
+}
+
+// logfmtEncoder logfmt 编码器：固定字段（时间/级别/名称/调用者/消息）在前，其余字段按 key 排序。
+// 嵌套对象与数组以 JSON 输出，含空格/引号/等号的值加引号。
+type logfmtEncoder struct {
+	*zapcore.MapObjectEncoder
+	cfg        zapcore.EncoderConfig
+	formatTime func(time.Time) string
+}
+
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := zapcore.NewMapObjectEncoder()
+	for k, v := range e.Fields {
+		clone.Fields[k] = v
+	}
+	return &logfmtEncoder{MapObjectEncoder: clone, cfg: e.cfg, formatTime: e.formatTime}
+}
+
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf := logBufferPool.Get()
+	add := func(key string, value any) {
+		if key == zapcore.OmitKey {
+			return
+		}
+		if buf.Len() > 0 {
+			buf.AppendByte(' ')
+		}
+		buf.AppendString(key)
+		buf.AppendByte('=')
+		buf.AppendString(logfmtValue(value))
+	}
+	if !ent.Time.IsZero() {
+		add(e.cfg.TimeKey, e.formatTime(ent.Time))
+	}
+	add(e.cfg.LevelKey, ent.Level.String())
+	if ent.LoggerName != "" {
+		add(e.cfg.NameKey, ent.LoggerName)
+	}
+	if ent.Caller.Defined {
+		add(e.cfg.CallerKey, ent.Caller.TrimmedPath())
+	}
+	add(e.cfg.MessageKey, ent.Message)
+
+	enc := e.Clone().(*logfmtEncoder)
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	keys := make([]string, 0, len(enc.Fields))
+	for key := range enc.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		add(key, enc.Fields[key])
+	}
+	if ent.Stack != "" {
+		add(e.cfg.StacktraceKey, ent.Stack)
+	}
+	buf.AppendString(e.cfg.LineEnding)
+	return buf, nil
+}
+
+// logfmtValue 格式化 logfmt 值：字符串按需加引号，对象/数组编码为 JSON。
+func logfmtValue(value any) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case error:
+		s = v.Error()
+	case fmt.Stringer:
+		s = v.String()
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			s = fmt.Sprint(v)
+		} else {
+			s = string(data)
+		}
+	default:
+		s = fmt.Sprint(v)
+	}
+	if s == "" || strings.IndexFunc(s, func(r rune) bool {
+		return r == ' ' || r == '=' || r == '"' || unicode.IsControl(r)
+	}) >= 0 {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,218 @@
+package dio
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RootLoggerName 根 logger 的名称（SetLogLevel/LogLevels 中代表全局级别）。
+const RootLoggerName = "root"
+
+// ErrLogLevelUnsupported 当前日志组件不支持运行期调整级别（未实现 LogLevelController）。
+var ErrLogLevelUnsupported = errors.New("dio logger does not support level control")
+
+// LogLevelController 支持运行期调整输出级别的日志组件（如 NewZapLogger 创建的 ZapLogger）。
+type LogLevelController interface {
+	// SetLevel 设置级别：name 为空或 RootLoggerName 时设置全局级别，否则设置 Named 前缀的级别；
+	// level 为空时移除该前缀的级别（回退到上级前缀或全局级别）。
+	SetLevel(name string, level string) error
+	// Levels 返回全局级别（RootLoggerName）与所有 Named 前缀的级别。
+	Levels() map[string]string
+}
+
+// logLevels 日志级别表：全局级别 + Named 前缀级别（前缀最长匹配），在 Named/Skip 派生的 logger 间共享。
+// zap core 以所有级别中的最低者（min）作为过滤门槛，具体 logger 是否输出由 enabled 按前缀判断。
+type logLevels struct {
+	mu    sync.RWMutex
+	root  zapcore.Level
+	named map[string]zapcore.Level
+	min   zap.AtomicLevel
+}
+
+func newLogLevels(root zapcore.Level) *logLevels {
+	return &logLevels{root: root, named: map[string]zapcore.Level{}, min: zap.NewAtomicLevelAt(root)}
+}
+
+// enabled 判断名为 name 的 logger 是否输出 lvl 级别：取最长匹配的 Named 前缀级别，无匹配时取全局级别。
+func (l *logLevels) enabled(name string, lvl zapcore.Level) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if len(l.named) == 0 || name == "" {
+		return lvl >= l.root
+	}
+	level, matched := l.root, ""
+	for prefix, v := range l.named {
+		if (name == prefix || strings.HasPrefix(name, prefix+".")) && len(prefix) > len(matched) {
+			level, matched = v, prefix
+		}
+	}
+	return lvl >= level
+}
+
+func (l *logLevels) rootLevel() zapcore.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.root
+}
+
+// set 设置全局（name 为空）或前缀级别，level 为 nil 时移除前缀级别。
+func (l *logLevels) set(name string, level *zapcore.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	switch {
+	case name == "":
+		if level != nil {
+			l.root = *level
+		}
+	case level == nil:
+		delete(l.named, name)
+	default:
+		l.named[name] = *level
+	}
+	minLevel := l.root
+	for _, v := range l.named {
+		if v < minLevel {
+			minLevel = v
+		}
+	}
+	l.min.SetLevel(minLevel)
+}
+
+func (l *logLevels) levels() map[string]string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	levels := make(map[string]string, len(l.named)+1)
+	levels[RootLoggerName] = l.root.String()
+	for name, v := range l.named {
+		levels[name] = v.String()
+	}
+	return levels
+}
+
+// parseLogLevel 解析级别名称：debug/info/warn(warning)/error，不区分大小写。
+func parseLogLevel(level string) (zapcore.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return zapcore.DebugLevel, nil
+	case "info":
+		return zapcore.InfoLevel, nil
+	case "warn", "warning":
+		return zapcore.WarnLevel, nil
+	case "error":
+		return zapcore.ErrorLevel, nil
+	default:
+		return zapcore.InfoLevel, fmt.Errorf("invalid log level %q, want debug/info/warn/error", level)
+	}
+}
+
+// SetLevel 运行期设置输出级别（见 LogLevelController）。WrapZapLogger 包装的外部 logger 不支持，返回 ErrLogLevelUnsupported。
+func (l *ZapLogger) SetLevel(name string, level string) error {
+	if l.levels == nil {
+		return ErrLogLevelUnsupported
+	}
+	if name == RootLoggerName {
+		name = ""
+	}
+	if level == "" {
+		if name == "" {
+			return errors.New("root log level cannot be removed")
+		}
+		l.levels.set(name, nil)
+		return nil
+	}
+	lvl, err := parseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	l.levels.set(name, &lvl)
+	return nil
+}
+
+// Levels 返回全局与 Named 前缀的级别（见 LogLevelController），不支持时返回 nil。
+func (l *ZapLogger) Levels() map[string]string {
+	if l.levels == nil {
+		return nil
+	}
+	return l.levels.levels()
+}
+
+// SetLogLevel 运行期调整日志级别：name 为空或 RootLoggerName 时调整全局级别，否则调整 Named 前缀（如 "[DIO]"）的级别；
+// level 取 debug/info/warn/error，为空时移除该前缀的级别。
+// Run 前调用返回 ErrNotRun，日志组件未实现 LogLevelController 时返回 ErrLogLevelUnsupported。
+func (d *dioContainer) SetLogLevel(name string, level string) error {
+	controller, err := d.logLevelController()
+	if err != nil {
+		return err
+	}
+	return controller.SetLevel(name, level)
+}
+
+// LogLevels 返回当前日志组件的级别表（RootLoggerName 为全局级别），不支持调整级别时返回 nil。
+func (d *dioContainer) LogLevels() map[string]string {
+	controller, err := d.logLevelController()
+	if err != nil {
+		return nil
+	}
+	return controller.Levels()
+}
+
+func (d *dioContainer) logLevelController() (LogLevelController, error) {
+	if d.log == nil {
+		return nil, ErrNotRun
+	}
+	controller, ok := d.log.(LogLevelController)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrLogLevelUnsupported, d.log)
+	}
+	return controller, nil
+}
+
+// applyLogLevels 按配置设置日志级别：log.level 为全局级别，log.levels.{name} 为 Named 前缀级别。
+// 日志组件不支持调整级别时忽略；级别名称非法时返回错误（Run 启动失败）。
+func (d *dioContainer) applyLogLevels() error {
+	controller, ok := d.log.(LogLevelController)
+	if !ok {
+		return nil
+	}
+	if level := d.GetPropertyString("log.level"); level != "" {
+		if err := controller.SetLevel("", level); err != nil {
+			return fmt.Errorf("log.level: %w", err)
+		}
+	}
+	levels := map[string]string{}
+	if m, ok := d.di.Property().Get("log.levels").(map[string]any); ok {
+		flattenLevels("", m, levels)
+	}
+	names := make([]string, 0, len(levels))
+	for name := range levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := controller.SetLevel(name, levels[name]); err != nil {
+			return fmt.Errorf("log.levels.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// flattenLevels 展开嵌套的 log.levels 配置（yaml 中 a.b: debug 会被解析为嵌套 map）。
+func flattenLevels(prefix string, m map[string]any, levels map[string]string) {
+	for key, value := range m {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]any); ok {
+			flattenLevels(name, nested, levels)
+		} else {
+			levels[name] = fmt.Sprint(value)
+		}
+	}
+}
@@ -0,0 +1,384 @@
+package dio
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 日志文件滚动的默认配置（log.pattern / log.rotation-time）。
+const (
+	defaultLogPattern      = "%Y-%m-%d"
+	defaultLogRotationTime = 24 * time.Hour
+)
+
+// rotateConfig 日志文件滚动配置：按时间间隔与文件大小滚动，按保留时长与数量清理历史文件。
+type rotateConfig struct {
+	pattern      string        // 文件名中的时间部分（strftime 风格）
+	rotationTime time.Duration // 按时间滚动的间隔
+	maxSize      int64         // 单个文件大小上限（字节），0 不限制
+	maxAge       time.Duration // 历史文件保留时长，0 不限制
+	maxBackups   int           // 历史文件保留数量，0 不限制
+	compress     bool          // 是否 gzip 压缩历史文件
+}
+
+// newRotateConfig 解析 ZapConfig 中的滚动配置。
+func newRotateConfig(c ZapConfig, maxAge time.Duration) (rotateConfig, error) {
+	config := rotateConfig{
+		pattern:      c.Pattern,
+		rotationTime: defaultLogRotationTime,
+		maxAge:       maxAge,
+		maxBackups:   c.MaxBackups,
+		compress:     c.Compress,
+	}
+	if config.pattern == "" {
+		config.pattern = defaultLogPattern
+	}
+	if _, err := patternRegexp(config.pattern); err != nil {
+		return config, err
+	}
+	if c.RotationTime != "" {
+		rotationTime, err := time.ParseDuration(c.RotationTime)
+		if err != nil || rotationTime <= 0 {
+			return config, fmt.Errorf("dio invalid log rotation-time %q", c.RotationTime)
+		}
+		config.rotationTime = rotationTime
+	}
+	if c.MaxSize != "" {
+		maxSize, err := parseByteSize(c.MaxSize)
+		if err != nil {
+			return config, err
+		}
+		config.maxSize = maxSize
+	}
+	if config.maxBackups < 0 {
+		config.maxBackups = 0
+	}
+	return config, nil
+}
+
+// parseByteSize 解析文件大小：支持 B/KB/MB/GB 后缀（不区分大小写，1KB=1024B），纯数字按 MB。
+func parseByteSize(size string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+	unit := int64(1 << 20)
+	for _, suffix := range []struct {
+		name string
+		unit int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, suffix.name) {
+			s, unit = strings.TrimSpace(strings.TrimSuffix(s, suffix.name)), suffix.unit
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("dio invalid log max-size %q", size)
+	}
+	return n * unit, nil
+}
+
+// strftime 风格的时间占位符。
+var patternLayouts = map[byte]string{
+	'Y': "2006",
+	'm': "01",
+	'd': "02",
+	'H': "15",
+	'M': "04",
+	'S': "05",
+}
+
+// formatPattern 按 strftime 风格的 pattern 格式化时间（%Y %m %d %H %M %S，%% 为 %）。
+func formatPattern(pattern string, t time.Time) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] == '%' && i+1 < len(pattern) {
+			i++
+			if layout, ok := patternLayouts[pattern[i]]; ok {
+				b.WriteString(t.Format(layout))
+			} else {
+				b.WriteByte(pattern[i])
+			}
+			continue
+		}
+		b.WriteByte(pattern[i])
+	}
+	return b.String()
+}
+
+// patternRegexp 生成匹配 pattern 格式化结果的正则，pattern 含不支持的占位符时返回错误。
+func patternRegexp(pattern string) (string, error) {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+			continue
+		}
+		if i+1 == len(pattern) {
+			return "", fmt.Errorf("dio invalid log pattern %q", pattern)
+		}
+		i++
+		if layout, ok := patternLayouts[pattern[i]]; ok {
+			b.WriteString(`\d{` + strconv.Itoa(len(layout)) + `}`)
+		} else if pattern[i] == '%' {
+			b.WriteString("%")
+		} else {
+			return "", fmt.Errorf("dio invalid log pattern %q: unsupported %%%c", pattern, pattern[i])
+		}
+	}
+	return b.String(), nil
+}
+
+// rotateWriter 滚动写入的日志文件：文件名为 {prefix}{pattern}.log，同一时间段内超过大小上限时
+// 依次写入 {prefix}{pattern}.1.log、.2.log ...；滚动后在后台压缩并清理历史文件。
+type rotateWriter struct {
+	prefix  string // 文件路径前缀（目录 + 名称 + "_"）
+	config  rotateConfig
+	matcher *regexp.Regexp // 匹配本 writer 的文件名（含历史文件与压缩文件）
+	now     func() time.Time
+
+	mu      sync.Mutex
+	file    *os.File
+	current string    // 当前写入的文件路径
+	period  time.Time // 当前文件所属时间段的起始时间
+	index   int       // 当前文件在时间段内的序号
+	size    int64
+
+	millMu      sync.Mutex // 串行化后台压缩/清理
+	pendingMu   sync.Mutex
+	pending     int        // 未完成的后台压缩/清理次数
+	pendingDone *sync.Cond // pending 归零时通知（Sync/Close 等待）
+}
+
+// newRotateWriter 创建滚动写入的日志文件（文件在首次写入时创建）。
+func newRotateWriter(prefix string, config rotateConfig) (*rotateWriter, error) {
+	expr, err := patternRegexp(config.pattern)
+	if err != nil {
+		return nil, err
+	}
+	base := filepath.Base(prefix)
+	matcher, err := regexp.Compile(`^` + regexp.QuoteMeta(base) + expr + `(\.(\d+))?\.log(\.gz)?$`)
+	if err != nil {
+		return nil, err
+	}
+	w := &rotateWriter{prefix: prefix, config: config, matcher: matcher, now: time.Now}
+	w.pendingDone = sync.NewCond(&w.pendingMu)
+	return w, nil
+}
+
+func (w *rotateWriter) Write(p []byte) (n int, err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.now()
+	if period := w.periodOf(now); w.file == nil || !period.Equal(w.period) {
+		err = w.openPeriod(period)
+	} else if w.config.maxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.config.maxSize {
+		err = w.openIndex(w.index + 1)
+	}
+	if err != nil {
+		return 0, err
+	}
+	n, err = w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Sync 刷盘并等待后台压缩/清理完成。
+func (w *rotateWriter) Sync() error {
+	w.mu.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Sync()
+	}
+	w.mu.Unlock()
+	w.waitMill()
+	return err
+}
+
+// Close 关闭当前文件并等待后台压缩/清理完成，之后再次写入会重新打开文件。
+func (w *rotateWriter) Close() error {
+	w.mu.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+	w.waitMill()
+	return err
+}
+
+// periodOf 返回 t 所属时间段的起始时间（按本地时区对齐，如 24h 间隔在本地零点滚动）。
+func (w *rotateWriter) periodOf(t time.Time) time.Time {
+	_, offset := t.Zone()
+	shift := time.Duration(offset) * time.Second
+	return t.Add(shift).Truncate(w.config.rotationTime).Add(-shift)
+}
+
+func (w *rotateWriter) filename(period time.Time, index int) string {
+	name := w.prefix + formatPattern(w.config.pattern, period)
+	if index > 0 {
+		name += "." + strconv.Itoa(index)
+	}
+	return name + ".log"
+}
+
+// openPeriod 切换到新的时间段：续写该时间段已有的最后一个文件（如进程重启），没有时从序号 0 开始。
+func (w *rotateWriter) openPeriod(period time.Time) error {
+	w.period = period
+	index := 0
+	for {
+		if _, err := os.Stat(w.filename(period, index+1)); err != nil {
+			if _, err := os.Stat(w.filename(period, index+1) + ".gz"); err != nil {
+				break
+			}
+		}
+		index++
+	}
+	return w.openIndex(index)
+}
+
+// openIndex 打开当前时间段内指定序号的文件（已存在且达到大小上限时顺延），并触发历史文件的压缩/清理。
+func (w *rotateWriter) openIndex(index int) error {
+	if w.file != nil {
+		_ = w.file.Close()
+		w.file = nil
+	}
+	for {
+		name := w.filename(w.period, index)
+		if _, err := os.Stat(name + ".gz"); err == nil {
+			index++
+			continue
+		}
+		info, err := os.Stat(name)
+		if err == nil && w.config.maxSize > 0 && info.Size() >= w.config.maxSize {
+			index++
+			continue
+		}
+		if err := checkDir(name); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		w.file, w.current, w.index, w.size = file, name, index, 0
+		if info != nil {
+			w.size = info.Size()
+		}
+		break
+	}
+	w.pendingMu.Lock()
+	w.pending++
+	w.pendingMu.Unlock()
+	go w.mill()
+	return nil
+}
+
+// waitMill 等待已触发的后台压缩/清理完成。
+func (w *rotateWriter) waitMill() {
+	w.pendingMu.Lock()
+	defer w.pendingMu.Unlock()
+	for w.pending > 0 {
+		w.pendingDone.Wait()
+	}
+}
+
+// mill 压缩并清理历史文件（当前写入的文件除外）：先按 compress 压缩，再按保留时长与数量删除最旧的文件。
+func (w *rotateWriter) mill() {
+	defer func() {
+		w.pendingMu.Lock()
+		w.pending--
+		w.pendingDone.Broadcast()
+		w.pendingMu.Unlock()
+	}()
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	dir := filepath.Dir(w.prefix)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	// 列出文件后再读取当前文件：列出的文件要么是当前文件，要么已滚动关闭
+	w.mu.Lock()
+	current := w.current
+	w.mu.Unlock()
+	type backup struct {
+		path    string
+		modTime time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		if entry.IsDir() || !w.matcher.MatchString(entry.Name()) {
+			continue
+		}
+		name := filepath.Join(dir, entry.Name())
+		if name == current {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if w.config.compress && !strings.HasSuffix(name, ".gz") {
+			if err := compressLogFile(name, info); err != nil {
+				continue
+			}
+			name += ".gz"
+		}
+		backups = append(backups, backup{path: name, modTime: info.ModTime()})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].modTime.Equal(backups[j].modTime) {
+			return backups[i].modTime.After(backups[j].modTime)
+		}
+		return backups[i].path > backups[j].path
+	})
+	cutoff := w.now().Add(-w.config.maxAge)
+	for i, b := range backups {
+		if (w.config.maxBackups > 0 && i >= w.config.maxBackups) || (w.config.maxAge > 0 && b.modTime.Before(cutoff)) {
+			_ = os.Remove(b.path)
+		}
+	}
+}
+
+// compressLogFile 将日志文件压缩为 .gz（保留修改时间，用于按保留时长清理），成功后删除原文件。
+func compressLogFile(name string, info os.FileInfo) (err error) {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(name+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(name + ".gz")
+		}
+	}()
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	_ = os.Chtimes(name+".gz", info.ModTime(), info.ModTime())
+	_ = src.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,226 @@
+package dio
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// 日志采样的默认配置（log.sampling.initial / thereafter / tick）。
+const (
+	defaultSamplingInitial    = 100
+	defaultSamplingThereafter = 100
+	defaultSamplingTick       = time.Second
+	maxSamplingKeys           = 10000 // 每个周期内参与计数的消息上限，超出后新消息不采样
+)
+
+// LogSampling 日志采样配置（log.sampling.* 前缀）：每个周期内同一消息与级别先输出 Initial 条，
+// 之后每 Thereafter 条输出 1 条，被抑制的条数在周期结束后以一条汇总日志输出。
+type LogSampling struct {
+	Initial    int           // 每个周期内先输出的条数，<=0 时为 100
+	Thereafter int           // 超过 Initial 后每 Thereafter 条输出 1 条，<=0 时全部抑制
+	Tick       time.Duration // 采样周期，<=0 时为 1s
+	Exclude    []string      // 不采样的 Named logger（前缀匹配，规则同 log.levels）
+}
+
+// logSampling 采样器的持有者，在 Named/Skip 派生的 logger 间共享；未设置采样器时为空操作。
+type logSampling struct {
+	sampler atomic.Pointer[logSampler]
+	logger  *zap.Logger // 输出汇总日志的根 logger（不带 Named 名称与 caller）
+}
+
+type sampleKey struct {
+	level zapcore.Level
+	msg   string
+}
+
+type sampleCount struct {
+	count      uint64
+	suppressed uint64
+}
+
+// sampleSummary 一个周期内某条消息被抑制的统计。
+type sampleSummary struct {
+	sampleKey
+	suppressed uint64
+}
+
+// logSampler 按周期计数的采样器：周期结束后重置计数并产出汇总。
+type logSampler struct {
+	config    LogSampling
+	mu        sync.Mutex
+	windowEnd time.Time
+	counts    map[sampleKey]*sampleCount
+	stop      chan struct{} // 关闭时结束定时汇总 goroutine
+	stopOnce  sync.Once
+}
+
+func newLogSampler(s LogSampling) *logSampler {
+	if s.Initial <= 0 {
+		s.Initial = defaultSamplingInitial
+	}
+	if s.Tick <= 0 {
+		s.Tick = defaultSamplingTick
+	}
+	return &logSampler{config: s, counts: map[sampleKey]*sampleCount{}, stop: make(chan struct{})}
+}
+
+// excluded 判断名为 name 的 logger 是否不参与采样。
+func (s *logSampler) excluded(name string) bool {
+	for _, prefix := range s.config.Exclude {
+		if name == prefix || strings.HasPrefix(name, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// sample 判断本条日志是否输出；周期已结束且汇总尚未由定时 goroutine 输出时一并返回。
+func (s *logSampler) sample(now time.Time, lvl zapcore.Level, msg string) (allow bool, summaries []sampleSummary) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !now.Before(s.windowEnd) {
+		summaries = s.reset()
+		s.windowEnd = now.Add(s.config.Tick)
+	}
+	key := sampleKey{level: lvl, msg: msg}
+	c, ok := s.counts[key]
+	if !ok {
+		if len(s.counts) >= maxSamplingKeys {
+			return true, summaries
+		}
+		c = &sampleCount{}
+		s.counts[key] = c
+	}
+	c.count++
+	if c.count <= uint64(s.config.Initial) {
+		return true, summaries
+	}
+	if s.config.Thereafter > 0 && (c.count-uint64(s.config.Initial))%uint64(s.config.Thereafter) == 0 {
+		return true, summaries
+	}
+	c.suppressed++
+	return false, summaries
+}
+
+// flush 周期已结束时返回汇总并结束该周期（由定时 goroutine 调用，之后的日志开启新周期）。
+func (s *logSampler) flush(now time.Time) []sampleSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.windowEnd.IsZero() || now.Before(s.windowEnd) {
+		return nil
+	}
+	s.windowEnd = time.Time{}
+	return s.reset()
+}
+
+// drain 结束当前周期并返回汇总（Close 时输出尚未汇总的抑制计数）。
+func (s *logSampler) drain() []sampleSummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.windowEnd = time.Time{}
+	return s.reset()
+}
+
+func (s *logSampler) reset() (summaries []sampleSummary) {
+	for key, c := range s.counts {
+		if c.suppressed > 0 {
+			summaries = append(summaries, sampleSummary{sampleKey: key, suppressed: c.suppressed})
+		}
+	}
+	s.counts = map[sampleKey]*sampleCount{}
+	return summaries
+}
+
+// sampled 判断本条日志是否输出（Named 名称在 Exclude 中时不采样），并输出上一周期的汇总。
+func (l *ZapLogger) sampled(lvl zapcore.Level, msg string) bool {
+	if l.sampling == nil {
+		return true
+	}
+	sampler := l.sampling.sampler.Load()
+	if sampler == nil || sampler.excluded(l.name) {
+		return true
+	}
+	allow, summaries := sampler.sample(time.Now(), lvl, msg)
+	l.logSampleSummaries(sampler, summaries)
+	return allow
+}
+
+// logSampleSummaries 以被抑制消息的级别输出汇总日志（消息同样经过脱敏）。
+func (l *ZapLogger) logSampleSummaries(sampler *logSampler, summaries []sampleSummary) {
+	for _, summary := range summaries {
+		msg, _ := l.redactor.redact(summary.msg, nil)
+		if ce := l.sampling.logger.Check(summary.level, "log sampling suppressed messages"); ce != nil {
+			ce.Write(zap.String("sampled_msg", msg), zap.Uint64("suppressed", summary.suppressed), zap.Duration("tick", sampler.config.Tick))
+		}
+	}
+}
+
+// logSamplingSetter 支持采样的日志组件（如 ZapLogger）。
+type logSamplingSetter interface {
+	SetSampling(s *LogSampling)
+}
+
+// stopSampling 结束采样器的定时汇总 goroutine 并输出尚未汇总的抑制计数。
+func (l *ZapLogger) stopSampling(sampler *logSampler) {
+	sampler.stopOnce.Do(func() { close(sampler.stop) })
+	l.logSampleSummaries(sampler, sampler.drain())
+}
+
+// flushSampling 每个采样周期检查一次，输出已结束周期的汇总：之后没有新日志时汇总也能及时输出。
+func (l *ZapLogger) flushSampling(sampler *logSampler) {
+	ticker := time.NewTicker(sampler.config.Tick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sampler.stop:
+			return
+		case now := <-ticker.C:
+			l.logSampleSummaries(sampler, sampler.flush(now))
+		}
+	}
+}
+
+// SetSampling 设置采样配置（替换已有的，nil 关闭采样并输出尚未汇总的抑制计数），在 Named/Skip 派生的 logger 间共享。
+// 采样期间由后台 goroutine 按周期输出汇总，Close 或替换采样配置时结束。
+func (l *ZapLogger) SetSampling(s *LogSampling) {
+	var sampler *logSampler
+	if s != nil {
+		sampler = newLogSampler(*s)
+		go l.flushSampling(sampler)
+	}
+	if previous := l.sampling.sampler.Swap(sampler); previous != nil {
+		l.stopSampling(previous)
+	}
+}
+
+// applyLogSampling 按 log.sampling.* 设置日志组件的采样（log.sampling.enabled=true 时生效）。
+func (d *dioContainer) applyLogSampling() error {
+	setter, ok := d.log.(logSamplingSetter)
+	if !ok || d.GetPropertyString("log.sampling.enabled") != "true" {
+		return nil
+	}
+	s := &LogSampling{
+		Initial:    defaultSamplingInitial,
+		Thereafter: defaultSamplingThereafter,
+		Tick:       d.durationProperty("log.sampling.tick", defaultSamplingTick),
+		Exclude:    d.propertyList("log.sampling.exclude"),
+	}
+	for key, target := range map[string]*int{"log.sampling.initial": &s.Initial, "log.sampling.thereafter": &s.Thereafter} {
+		if v := d.GetPropertyString(key); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return fmt.Errorf("%s: invalid number %q", key, v)
+			}
+			*target = n
+		}
+	}
+	setter.SetSampling(s)
+	return nil
+}
@@ -0,0 +1,431 @@
+package dio
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cheivin/dio-core"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// 内置的日志输出类型（log.outputs[].type）。
+const (
+	LogSinkStdout       = "stdout"
+	LogSinkStderr       = "stderr"
+	LogSinkFile         = "file"          // 单个文件（path）
+	LogSinkRotatingFile = "rotating-file" // 滚动文件（path 及 log.* 同名滚动配置）
+	LogSinkSyslog       = "syslog"        // 本机 syslog（unix socket）
+)
+
+// ErrLogSinkUnknown log.outputs 中的输出类型既非内置类型，也未通过 RegisterLogSink 注册。
+var ErrLogSinkUnknown = errors.New("dio unknown log sink type")
+
+// LogOutput 日志输出（log.outputs 中的一项）。
+type LogOutput struct {
+	Type    string         // 输出类型：stdout/stderr/file/rotating-file/syslog，或 RegisterLogSink 注册的名称
+	Level   string         // 该输出的最低级别（debug/info/warn/error），默认不额外限制（跟随全局与 Named 前缀级别）
+	Format  string         // 该输出的格式（console/json/logfmt），默认取 log.format
+	Options map[string]any // 类型相关的配置（如 path、address、tag）
+	Writer  io.Writer      // 直接指定 writer（手动创建日志组件时使用，优先于 Type）
+}
+
+// LogSinkFactory 按输出配置创建 writer（RegisterLogSink 注册）。
+// writer 实现 io.Closer 时由日志组件在 Close/Destroy 时关闭，实现 zapcore.WriteSyncer 时随 flush 调用 Sync。
+type LogSinkFactory func(output LogOutput) (io.Writer, error)
+
+// option 读取输出配置项的字符串值，未配置时返回 defaultValue。
+func (o LogOutput) option(key string, defaultValue string) string {
+	if v, ok := o.Options[key]; ok && v != nil {
+		return fmt.Sprint(v)
+	}
+	return defaultValue
+}
+
+// parseLogOutputs 解析 log.outputs 配置（yaml 列表，每项含 type/level/format 及类型相关的配置）。
+func parseLogOutputs(value any) ([]LogOutput, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("log.outputs: want a list, got %T", value)
+	}
+	outputs := make([]LogOutput, 0, len(items))
+	for i, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("log.outputs[%d]: want a map, got %T", i, item)
+		}
+		output := LogOutput{Options: map[string]any{}}
+		for key, v := range m {
+			switch key {
+			case "type":
+				output.Type = fmt.Sprint(v)
+			case "level":
+				output.Level = fmt.Sprint(v)
+			case "format":
+				output.Format = fmt.Sprint(v)
+			default:
+				output.Options[key] = v
+			}
+		}
+		if output.Type == "" {
+			return nil, fmt.Errorf("log.outputs[%d]: type is required", i)
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs, nil
+}
+
+// NewZapLoggerWithOutputs 按 outputs 创建日志组件：各输出有独立的级别与格式，替代 l 中的 file/std 输出。
+// 支持内置类型与直接指定 Writer 的输出；RegisterLogSink 注册的类型仅在 Run 创建日志组件时可用。
+func NewZapLoggerWithOutputs(l core.Property, c ZapConfig, outputs []LogOutput, opts ...zap.Option) (core.Log, error) {
+	return newZapLogger(l, c, outputs, nil, opts...)
+}
+
+// newLogSink 创建输出的 writer：优先使用 Writer，其次内置类型，最后为注册的类型。
+func newLogSink(output LogOutput, c ZapConfig, maxAge time.Duration, factories map[string]LogSinkFactory) (io.Writer, error) {
+	if output.Writer != nil {
+		return output.Writer, nil
+	}
+	switch strings.ToLower(output.Type) {
+	case LogSinkStdout:
+		return os.Stdout, nil
+	case LogSinkStderr:
+		return os.Stderr, nil
+	case LogSinkFile:
+		path := output.option("path", "")
+		if path == "" {
+			return nil, errors.New("path is required")
+		}
+		return &fileSink{path: path}, nil
+	case LogSinkRotatingFile:
+		return newRotatingFileSink(output, c, maxAge)
+	case LogSinkSyslog:
+		return newSyslogSink(output)
+	}
+	if factory, ok := factories[output.Type]; ok {
+		return factory(output)
+	}
+	known := []string{LogSinkStdout, LogSinkStderr, LogSinkFile, LogSinkRotatingFile, LogSinkSyslog}
+	for name := range factories {
+		known = append(known, name)
+	}
+	sort.Strings(known[5:])
+	return nil, fmt.Errorf("%w %q, want one of %s", ErrLogSinkUnknown, output.Type, strings.Join(known, "/"))
+}
+
+// newSinkCores 创建输出对应的 core：该输出的级别与格式叠加在全局级别（enab）之上。
+// 按级别区分写入的 writer（如 syslog）为每个级别创建一个 core。
+func newSinkCores(output LogOutput, writer io.Writer, c ZapConfig, enab zapcore.LevelEnabler,
+	newCore func(zapcore.Encoder, zapcore.WriteSyncer, zapcore.LevelEnabler) zapcore.Core) ([]zapcore.Core, error) {
+	format := output.Format
+	if format == "" {
+		format = c.Format
+	}
+	color := (writer == os.Stdout || writer == os.Stderr) && !strings.EqualFold(c.Color, "false")
+	encoder, err := newLogEncoder(format, color, c)
+	if err != nil {
+		return nil, err
+	}
+	minLevel := zapcore.DebugLevel
+	if output.Level != "" {
+		if minLevel, err = parseLogLevel(output.Level); err != nil {
+			return nil, err
+		}
+	}
+	leveled, ok := writer.(levelWriter)
+	if !ok {
+		ws := zapcore.AddSync(writer)
+		if f, ok := writer.(*os.File); ok {
+			ws = zapcore.Lock(f)
+		}
+		return []zapcore.Core{newCore(encoder, ws, zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+			return lvl >= minLevel && enab.Enabled(lvl)
+		}))}, nil
+	}
+	var cores []zapcore.Core
+	for lvl := zapcore.DebugLevel; lvl <= zapcore.FatalLevel; lvl++ {
+		level := lvl
+		cores = append(cores, newCore(encoder.Clone(), zapcore.AddSync(leveled.writerFor(level)), zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+			return lvl == level && lvl >= minLevel && enab.Enabled(lvl)
+		})))
+	}
+	return cores, nil
+}
+
+// levelWriter 按级别区分写入的输出（如 syslog 的 severity）。
+type levelWriter interface {
+	writerFor(lvl zapcore.Level) io.Writer
+}
+
+// onceCloser 保证注册输出的 Close 只调用一次（Destroy 与 Run 失败清理可能先后调用 Close）。
+type onceCloser struct {
+	closer io.Closer
+	once   sync.Once
+}
+
+func (c *onceCloser) Close() (err error) {
+	c.once.Do(func() {
+		err = c.closer.Close()
+	})
+	return err
+}
+
+// fileSink 追加写入的单个文件，首次写入时创建；Close 后再次写入会重新打开（Restart 复用日志组件）。
+type fileSink struct {
+	path string
+	mu   sync.Mutex
+	file *os.File
+}
+
+func (s *fileSink) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		if err := checkDir(s.path); err != nil {
+			return 0, err
+		}
+		file, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return 0, err
+		}
+		s.file = file
+	}
+	return s.file.Write(p)
+}
+
+func (s *fileSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Sync()
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// newRotatingFileSink 创建滚动文件输出：path 为 {dir}/{name}(.log)，滚动配置默认取 log.* 同名配置，可在输出中覆盖
+// （pattern、rotation-time、max-size、max-backups、max-age、compress）。
+func newRotatingFileSink(output LogOutput, c ZapConfig, maxAge time.Duration) (io.Writer, error) {
+	path := output.option("path", "")
+	if path == "" {
+		return nil, errors.New("path is required")
+	}
+	c.Pattern = output.option("pattern", c.Pattern)
+	c.RotationTime = output.option("rotation-time", c.RotationTime)
+	c.MaxSize = output.option("max-size", c.MaxSize)
+	var err error
+	if v := output.option("max-backups", ""); v != "" {
+		if c.MaxBackups, err = strconv.Atoi(v); err != nil {
+			return nil, fmt.Errorf("invalid max-backups %q", v)
+		}
+	}
+	if v := output.option("max-age", ""); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max-age %q", v)
+		}
+		maxAge = time.Duration(days) * 24 * time.Hour
+	}
+	if v := output.option("compress", ""); v != "" {
+		if c.Compress, err = strconv.ParseBool(v); err != nil {
+			return nil, fmt.Errorf("invalid compress %q", v)
+		}
+	}
+	rotate, err := newRotateConfig(c, maxAge)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".log") {
+		path += ".log"
+	}
+	return getLogWriter(path, rotate)
+}
+
+// syslog facility 名称（log.outputs[].facility）。
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// syslogSink 写入本机 syslog 的输出（RFC 3164 格式，unix socket），按日志级别映射 severity。
+// 连接在首次写入时建立，写入失败时重连一次；Close 后再次写入会重新连接。
+type syslogSink struct {
+	network  string // unixgram / unix，为空时依次尝试
+	address  string // socket 路径，为空时依次尝试 /dev/log、/var/run/syslog、/var/run/log
+	tag      string
+	facility int
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// newSyslogSink 按输出配置（network、address、tag、facility）创建 syslog 输出。
+func newSyslogSink(output LogOutput) (*syslogSink, error) {
+	facility, ok := syslogFacilities[strings.ToLower(output.option("facility", "user"))]
+	if !ok {
+		return nil, fmt.Errorf("invalid syslog facility %q", output.option("facility", ""))
+	}
+	tag := output.option("tag", "")
+	if tag == "" && len(os.Args) > 0 {
+		tag = os.Args[0][strings.LastIndexAny(os.Args[0], `/\`)+1:]
+	}
+	return &syslogSink{network: output.option("network", ""), address: output.option("address", ""), tag: tag, facility: facility}, nil
+}
+
+func (s *syslogSink) dial() (net.Conn, error) {
+	networks := []string{"unixgram", "unix"}
+	if s.network != "" {
+		networks = []string{s.network}
+	}
+	addresses := []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+	if s.address != "" {
+		addresses = []string{s.address}
+	}
+	var lastErr error
+	for _, network := range networks {
+		for _, address := range addresses {
+			conn, err := net.Dial(network, address)
+			if err == nil {
+				return conn, nil
+			}
+			lastErr = err
+		}
+	}
+	return nil, fmt.Errorf("dio syslog unavailable: %w", lastErr)
+}
+
+// write 以 severity 写入一条日志。
+func (s *syslogSink) write(severity int, p []byte) (int, error) {
+	msg := strings.TrimRight(string(p), "\n")
+	line := fmt.Sprintf("<%d>%s %s[%d]: %s\n", s.facility*8+severity, time.Now().Format(time.Stamp), s.tag, os.Getpid(), msg)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for attempt := 0; ; attempt++ {
+		if s.conn == nil {
+			conn, err := s.dial()
+			if err != nil {
+				return 0, err
+			}
+			s.conn = conn
+		}
+		_, err := s.conn.Write([]byte(line))
+		if err == nil {
+			return len(p), nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+		if attempt > 0 {
+			return 0, err
+		}
+	}
+}
+
+// Write 以 INFO 对应的 severity 写入（按级别写入见 writerFor）。
+func (s *syslogSink) Write(p []byte) (int, error) {
+	return s.write(syslogSeverity(zapcore.InfoLevel), p)
+}
+
+func (s *syslogSink) writerFor(lvl zapcore.Level) io.Writer {
+	return syslogLevelWriter{sink: s, severity: syslogSeverity(lvl)}
+}
+
+func (s *syslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+type syslogLevelWriter struct {
+	sink     *syslogSink
+	severity int
+}
+
+func (w syslogLevelWriter) Write(p []byte) (int, error) {
+	return w.sink.write(w.severity, p)
+}
+
+// syslogSeverity 日志级别映射为 syslog severity。
+func syslogSeverity(lvl zapcore.Level) int {
+	switch {
+	case lvl <= zapcore.DebugLevel:
+		return 7 // debug
+	case lvl == zapcore.InfoLevel:
+		return 6 // info
+	case lvl == zapcore.WarnLevel:
+		return 4 // warning
+	case lvl == zapcore.ErrorLevel:
+		return 3 // err
+	default:
+		return 2 // crit
+	}
+}
+
+// RegisterLogSink 日志输出插件：注册名为 name 的输出类型，log.outputs 中 type 为 name 的输出由 factory 创建。
+//
+//	dio.Use(dio.RegisterLogSink("kafka", func(output dio.LogOutput) (io.Writer, error) {
+//		return newKafkaWriter(output.Options["topic"])
+//	}))
+func RegisterLogSink(name string, factory LogSinkFactory) core.PluginConfig {
+	return func(d core.Dio) {
+		c := d.(*dioContainer)
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.logSinks == nil {
+			c.logSinks = map[string]LogSinkFactory{}
+		}
+		c.logSinks[name] = factory
+	}
+}
+
+// RegisterLogWriter 日志输出插件：注册名为 name、写入 w 的输出类型（RegisterLogSink 的简化形式）。
+// w 由调用方持有，日志组件 Close / Destroy 时不会关闭它（Restart 重建日志组件后继续写入同一 w）。
+//
+//	dio.Use(dio.RegisterLogWriter("audit", auditWriter))
+func RegisterLogWriter(name string, w io.Writer) core.PluginConfig {
+	return RegisterLogSink(name, func(LogOutput) (io.Writer, error) {
+		return unownedWriter{Writer: w}, nil
+	})
+}
+
+// unownedWriter 隐藏调用方 writer 的 Close：RegisterLogWriter 注册的 writer 跨多次 Run 复用，不随日志组件关闭。
+type unownedWriter struct {
+	io.Writer
+}
+
+// logSinkFactories 返回已注册的输出类型。
+func (d *dioContainer) logSinkFactories() map[string]LogSinkFactory {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	factories := make(map[string]LogSinkFactory, len(d.logSinks))
+	for name, factory := range d.logSinks {
+		factories[name] = factory
+	}
+	return factories
+}
@@ -0,0 +1,166 @@
+package dio
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// 默认的 trace id / span id 字段 key（log.trace-key / log.span-key 可覆盖）。
+const (
+	defaultTraceKey = "trace_id"
+	defaultSpanKey  = "span_id"
+)
+
+// ErrInvalidTraceParent traceparent 格式非法（W3C Trace Context：00-{32 位 trace-id}-{16 位 parent-id}-{2 位 flags}）。
+var ErrInvalidTraceParent = errors.New("dio invalid traceparent")
+
+// TraceContext 请求的追踪上下文（W3C Trace Context）。
+type TraceContext struct {
+	TraceID  string // 32 位小写十六进制；TraceWith 传入非 traceparent 字符串时为该字符串原值
+	SpanID   string // 当前 span id，16 位小写十六进制
+	ParentID string // 上游 span id（由 traceparent 解析得到时有值）
+	Sampled  bool   // 是否采样（trace-flags 的 sampled 位）
+}
+
+// TraceParent 返回 W3C traceparent 头的值（用于向下游传播），TraceID 不是 W3C 格式时返回空串。
+func (t TraceContext) TraceParent() string {
+	if !isTraceHex(t.TraceID, 32) || !isTraceHex(t.SpanID, 16) {
+		return ""
+	}
+	flags := "00"
+	if t.Sampled {
+		flags = "01"
+	}
+	return "00-" + t.TraceID + "-" + t.SpanID + "-" + flags
+}
+
+// NewTraceContext 生成新的追踪上下文（随机 trace id 与 span id，标记为采样）。
+func NewTraceContext() TraceContext {
+	return TraceContext{TraceID: randomTraceHex(16), SpanID: randomTraceHex(8), Sampled: true}
+}
+
+// ParseTraceParent 解析 W3C traceparent 头：上游 span id 作为 ParentID，并生成本服务的 SpanID。
+func ParseTraceParent(traceParent string) (TraceContext, error) {
+	parts := strings.Split(strings.TrimSpace(traceParent), "-")
+	if len(parts) < 4 || !isTraceHex(parts[0], 2) || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) ||
+		!isTraceHex(parts[1], 32) || !isTraceHex(parts[2], 16) || !isTraceHex(parts[3], 2) {
+		return TraceContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceParent, traceParent)
+	}
+	flags, _ := hex.DecodeString(parts[3])
+	return TraceContext{TraceID: parts[1], SpanID: randomTraceHex(8), ParentID: parts[2], Sampled: flags[0]&0x01 == 1}, nil
+}
+
+// isTraceHex 判断是否为 n 位小写十六进制且不全为 0（W3C 规定全 0 的 id 无效，version/flags 除外）。
+func isTraceHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	zero := true
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+		if r != '0' {
+			zero = false
+		}
+	}
+	return n == 2 || !zero
+}
+
+func randomTraceHex(n int) string {
+	b := make([]byte, n)
+	for {
+		_, _ = rand.Read(b)
+		for _, v := range b {
+			if v != 0 {
+				return hex.EncodeToString(b)
+			}
+		}
+	}
+}
+
+// SpanContextReader 从 ctx 读取外部追踪系统（如 OpenTelemetry）的 span 上下文，ctx 中没有时返回 false。
+// 对接 OpenTelemetry 示例：
+//
+//	dio.SetSpanContextReader(func(ctx context.Context) (dio.TraceContext, bool) {
+//		sc := trace.SpanContextFromContext(ctx)
+//		if !sc.IsValid() {
+//			return dio.TraceContext{}, false
+//		}
+//		return dio.TraceContext{TraceID: sc.TraceID().String(), SpanID: sc.SpanID().String(), Sampled: sc.IsSampled()}, true
+//	})
+type SpanContextReader func(ctx context.Context) (TraceContext, bool)
+
+var spanContextReader atomic.Pointer[SpanContextReader]
+
+// SetSpanContextReader 设置进程级的 span 上下文读取器（传 nil 移除）。
+// 设置后 ZapLogger 优先从读取器获取 trace id / span id，读取不到时再使用 Trace/TraceWith 写入 ctx 的追踪上下文。
+func SetSpanContextReader(reader SpanContextReader) {
+	if reader == nil {
+		spanContextReader.Store(nil)
+		return
+	}
+	spanContextReader.Store(&reader)
+}
+
+// readSpanContext 调用 span 上下文读取器，读取器 panic 时视为读取不到。
+func readSpanContext(ctx context.Context) (trace TraceContext, ok bool) {
+	reader := spanContextReader.Load()
+	if reader == nil {
+		return TraceContext{}, false
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			trace, ok = TraceContext{}, false
+		}
+	}()
+	return (*reader)(ctx)
+}
+
+// TraceContext 返回 ctx 中的追踪上下文：优先 SetSpanContextReader 设置的外部读取器，其次 Trace/TraceWith 写入的值。
+func (l *ZapLogger) TraceContext(ctx context.Context) (TraceContext, bool) {
+	return traceContextFrom(ctx, l.traceName)
+}
+
+// traceContextFrom 读取追踪上下文：优先外部 span 上下文读取器，其次 ctx 中 traceName 对应的值。
+func traceContextFrom(ctx context.Context, traceName string) (TraceContext, bool) {
+	if trace, ok := readSpanContext(ctx); ok && trace.TraceID != "" {
+		return trace, true
+	}
+	if traceName != "" {
+		if trace, ok := ctx.Value(traceContextKey{name: traceName}).(TraceContext); ok {
+			return trace, true
+		}
+	}
+	return TraceContext{}, false
+}
+
+// traceContextOf 将 TraceWith 的参数转换为追踪上下文：TraceContext 原样使用，traceparent 字符串解析，
+// 其他非空字符串原值作为 trace id 并生成 span id；其他类型返回 false。
+func traceContextOf(val any) (TraceContext, bool) {
+	switch v := val.(type) {
+	case TraceContext:
+		return v, true
+	case string:
+		if v == "" {
+			return TraceContext{}, false
+		}
+		if trace, err := ParseTraceParent(v); err == nil {
+			return trace, true
+		}
+		return TraceContext{TraceID: v, SpanID: randomTraceHex(8)}, true
+	default:
+		return TraceContext{}, false
+	}
+}
+
+// TraceParent 返回 ctx 中追踪上下文的 W3C traceparent 值（用于向下游传播），没有追踪上下文时返回空串。
+func (l *ZapLogger) TraceParent(ctx context.Context) string {
+	trace, _ := l.TraceContext(ctx)
+	return trace.TraceParent()
+}
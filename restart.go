@@ -0,0 +1,62 @@
+package dio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cheivin/di"
+	"github.com/cheivin/dio-core"
+)
+
+// record 执行一次作用于 di 容器的操作并记录，Restart 重建 di 容器时按原顺序重放。
+// 操作内部须通过 d.di 访问容器（而非捕获当时的实例），重放时才会作用到新容器上。
+func (d *dioContainer) record(op func()) {
+	op()
+	d.mu.Lock()
+	d.diOps = append(d.diOps, op)
+	d.mu.Unlock()
+}
+
+// Restart 重置容器并重新执行完整生命周期，阻塞直到停机（语义同 Run）。
+// 仅可在 Stopped 或 Failed 状态调用（Pending 时等价于 Run），其他状态 panic（ErrAlreadyRun）。
+// 重置过程：新建 di 容器 → 按原顺序重放配置与直接注册（SetProperty/LoadConfig/AutoMigrateEnv/SetLogger/ProvideFunc 等）
+// → Run 时按当前配置重新匹配 Provide/RegisterBean 的条件并注册。
+// LoadConfig 等配置文件来源在重放时重新读取一次（ReloadConfig 不产生新的记录）。
+// 状态变更回调、停机回调、信号处理器与失败分析器保留；Run 期间直接注册进 di 的实例（RegisterBean 运行期注册）不保留。
+// Run 创建的日志组件已在停机时随容器销毁（关闭异步缓冲与输出），重启时按当前 log.* 配置重新创建；
+// SetLogger 设置的日志组件由调用方负责，重启后仍使用同一实例。
+func (d *dioContainer) Restart(ctx context.Context, afterRunFns ...func(core.Dio)) {
+	switch state := d.State(); state {
+	case Pending:
+	case Stopped, Failed:
+		d.resetDI()
+	default:
+		panic(fmt.Errorf("%w: cannot restart in state %s", ErrAlreadyRun, state))
+	}
+	d.Run(ctx, afterRunFns...)
+}
+
+// resetDI 新建 di 容器并重放已记录的操作，还原 loaded 以允许再次 Run。
+func (d *dioContainer) resetDI() {
+	d.mu.Lock()
+	ops := append([]func(){}, d.diOps...)
+	d.beanTimings = nil
+	d.beanTypes = nil
+	d.beanOrigins = nil
+	d.factoryInputs = nil
+	d.mu.Unlock()
+
+	// slog 默认 logger 不再指向已关闭的日志组件，Run 时按 log.slog-default 重新设置
+	d.restoreDefaultSlog()
+	// 丢弃 Run 创建的日志组件（已关闭），Run 时重新创建
+	if d.logCreated {
+		d.log = nil
+		d.logCreated = false
+	}
+	d.di = di.New()
+	d.di.Log(emptyLogger{})
+	for _, op := range ops {
+		op()
+	}
+	d.loaded = false
+}
@@ -0,0 +1,163 @@
+package dio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+
+	"github.com/cheivin/dio-core"
+)
+
+// signalHandler 自定义信号处理器（OnSignal 注册）。
+type signalHandler struct {
+	sig os.Signal
+	fn  func(context.Context)
+}
+
+// OnSignal 注册自定义信号处理器，仅在 Running 状态下生效（如 SIGHUP 重载配置、SIGUSR1 输出诊断信息）。
+// 同一信号可注册多个处理器，按注册顺序执行；所有处理器在同一个 goroutine 中串行执行，互不并发。
+// 处理器的 ctx 在容器停机时取消；处理器 panic 会被恢复并记录日志，不影响后续信号。
+// 注意：SIGINT/SIGTERM 固定用于触发停机，不应在此注册。必须在 Run 前调用。
+func (d *dioContainer) OnSignal(sig os.Signal, fn func(context.Context)) core.Dio {
+	d.mu.Lock()
+	d.signalHandlers = append(d.signalHandlers, signalHandler{sig: sig, fn: fn})
+	d.mu.Unlock()
+	return d
+}
+
+// serveSignals 启动自定义信号分发 goroutine，返回的 stop 函数停止监听并等待正在执行的处理器结束。
+// 未注册任何处理器时不监听信号。
+func (d *dioContainer) serveSignals(ctx context.Context) (stop func()) {
+	d.mu.Lock()
+	handlers := append([]signalHandler(nil), d.signalHandlers...)
+	d.mu.Unlock()
+	if len(handlers) == 0 {
+		return func() {}
+	}
+	sigs := make([]os.Signal, 0, len(handlers))
+	for _, h := range handlers {
+		sigs = append(sigs, h.sig)
+	}
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, sigs...)
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case sig := <-ch:
+				// 仅 Running 状态下分发（停机阶段收到的信号直接丢弃）
+				if d.State() != Running {
+					continue
+				}
+				for _, h := range handlers {
+					if h.sig == sig {
+						d.runSignalHandler(ctx, sig, h.fn)
+					}
+				}
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(ch)
+		cancel()
+		<-done
+	}
+}
+
+// runSignalHandler 执行单个信号处理器，恢复其 panic 以保证分发 goroutine 继续工作。
+func (d *dioContainer) runSignalHandler(ctx context.Context, sig os.Signal, fn func(context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			d.log.Error(ctx, fmt.Sprintf("signal handler for %s panic: %v", sig, r))
+		}
+	}()
+	fn(ctx)
+}
+
+// ReloadConfigOnSignal 内置信号处理插件：收到 sig 时重新读取 LoadDefaultConfig/LoadConfig/LoadConfigDir 加载过的配置文件。
+// 配置按原加载顺序重放（AutoMigrateEnv 开启时随后重新迁移环境变量），只覆盖/新增配置项，文件中删除的配置项不会被移除。
+// 已注入 bean 的 value 字段不会更新，需要感知新配置的代码应通过 GetPropertyString/GetProperties 读取。
+//
+//	dio.Use(dio.ReloadConfigOnSignal(syscall.SIGHUP))
+func ReloadConfigOnSignal(sig os.Signal) core.PluginConfig {
+	return func(d core.Dio) {
+		c := d.(*dioContainer)
+		c.OnSignal(sig, func(ctx context.Context) {
+			if err := c.ReloadConfig(); err != nil {
+				c.log.Error(ctx, fmt.Sprintf("reload config failed: %v", err))
+				return
+			}
+			c.log.Info(ctx, "config reloaded")
+		})
+	}
+}
+
+// debugToggler 支持运行期切换 DEBUG 级别的日志组件（如 ZapLogger）。
+type debugToggler interface {
+	ToggleDebug() (debug bool)
+}
+
+// ToggleDebugOnSignal 内置信号处理插件：收到 sig 时在 DEBUG 与 INFO 级别之间切换日志输出级别。
+// 仅对支持级别切换的日志组件（NewZapLogger 创建的 ZapLogger）生效，其他日志组件记录警告后忽略。
+//
+//	dio.Use(dio.ToggleDebugOnSignal(syscall.SIGUSR2))
+func ToggleDebugOnSignal(sig os.Signal) core.PluginConfig {
+	return func(d core.Dio) {
+		c := d.(*dioContainer)
+		c.OnSignal(sig, func(ctx context.Context) {
+			toggler, ok := c.log.(debugToggler)
+			if !ok {
+				c.log.Warn(ctx, fmt.Sprintf("logger %T does not support level toggling", c.log))
+				return
+			}
+			c.log.Info(ctx, fmt.Sprintf("log debug level: %t", toggler.ToggleDebug()))
+		})
+	}
+}
+
+// DumpDiagnosticsOnSignal 内置信号处理插件：收到 sig 时通过日志输出诊断信息
+// （容器状态、运行时长、bean 列表、内存统计与全部 goroutine 堆栈）。
+//
+//	dio.Use(dio.DumpDiagnosticsOnSignal(syscall.SIGUSR1))
+func DumpDiagnosticsOnSignal(sig os.Signal) core.PluginConfig {
+	return func(d core.Dio) {
+		c := d.(*dioContainer)
+		c.OnSignal(sig, c.dumpDiagnostics)
+	}
+}
+
+// dumpDiagnostics 输出容器与运行时诊断信息。
+func (d *dioContainer) dumpDiagnostics(ctx context.Context) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	d.log.Info(ctx, "diagnostics",
+		"state", d.State().String(),
+		"uptime", d.StartupDuration().Round(time.Millisecond).String(),
+		"beans", d.GetBeanNames(),
+		"goroutines", runtime.NumGoroutine(),
+		"heapAlloc", mem.HeapAlloc,
+		"heapSys", mem.HeapSys,
+		"numGC", mem.NumGC,
+	)
+	d.log.Info(ctx, "goroutine dump\n"+string(goroutineStacks()))
+}
+
+// goroutineStacks 返回全部 goroutine 的堆栈（缓冲不足时倍增重试）。
+func goroutineStacks() []byte {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,291 @@
+package dio
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/cheivin/dio-core"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slogHandler 基于 core.Log 的 slog.Handler：slog 的日志经由 core.Log 输出。
+type slogHandler struct {
+	log    core.Log
+	attrs  []any  // WithAttrs 累积的字段（key/value 交替，key 已带分组前缀）
+	groups string // WithGroup 累积的分组前缀（如 "req.header."）
+}
+
+// NewSlogHandler 创建基于 core.Log（如 ZapLogger）的 slog.Handler，使 log/slog 的日志与 dio 日志统一输出：
+//   - 级别映射：< INFO 为 DEBUG，< WARN 为 INFO，< ERROR 为 WARN，其余为 ERROR
+//   - 属性按 key/value 传给 core.Log，分组以 "." 连接为 key 前缀（如 req.method）
+//   - ctx 原样传递，trace id 等上下文字段由 core.Log 输出
+//
+// 基于 ZapLogger 时遵循其 Named 前缀级别，调用者信息取自 slog 的调用位置。
+func NewSlogHandler(log core.Log) slog.Handler {
+	return &slogHandler{log: log}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	if l, ok := h.log.(*ZapLogger); ok {
+		lvl := zapLevel(level)
+		return (l.levels == nil || l.levels.enabled(l.name, lvl)) && l.logger.Desugar().Core().Enabled(lvl)
+	}
+	return true
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := append([]any(nil), h.attrs...)
+	r.Attrs(func(attr slog.Attr) bool {
+		fields = appendSlogAttr(fields, h.groups, attr)
+		return true
+	})
+	if l, ok := h.log.(*ZapLogger); ok {
+		l.logAt(ctx, zapLevel(r.Level), r.Message, r.PC, fields)
+		return nil
+	}
+	switch lvl := zapLevel(r.Level); lvl {
+	case zapcore.DebugLevel:
+		h.log.Debug(ctx, r.Message, fields...)
+	case zapcore.InfoLevel:
+		h.log.Info(ctx, r.Message, fields...)
+	case zapcore.WarnLevel:
+		h.log.Warn(ctx, r.Message, fields...)
+	default:
+		h.log.Error(ctx, r.Message, fields...)
+	}
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.attrs = append([]any(nil), h.attrs...)
+	for _, attr := range attrs {
+		handler.attrs = appendSlogAttr(handler.attrs, h.groups, attr)
+	}
+	return &handler
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.groups = h.groups + name + "."
+	return &handler
+}
+
+// appendSlogAttr 展开 slog 属性为 key/value：分组属性递归展开并以 "." 连接 key，空属性忽略。
+func appendSlogAttr(fields []any, prefix string, attr slog.Attr) []any {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = prefix + attr.Key + "."
+		}
+		for _, a := range attr.Value.Group() {
+			fields = appendSlogAttr(fields, groupPrefix, a)
+		}
+		return fields
+	}
+	return append(fields, prefix+attr.Key, attr.Value.Any())
+}
+
+// zapLevel slog 级别映射到 zap 级别。
+func zapLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// logAt 以指定调用位置（pc）输出日志，供 slog 桥接保留 slog 调用方的 caller 信息。
+func (l *ZapLogger) logAt(ctx context.Context, lvl zapcore.Level, msg string, pc uintptr, fields []any) {
+	if l.levels != nil && !l.levels.enabled(l.name, lvl) {
+		return
+	}
+	if !l.logger.Desugar().Core().Enabled(lvl) || !l.sampled(lvl, msg) {
+		return
+	}
+	msg, kvs := l.redactor.redact(msg, append(l.contextFields(ctx), fields...))
+	ce := l.logger.Desugar().Check(lvl, msg)
+	if ce == nil {
+		return
+	}
+	if pc != 0 && ce.Caller.Defined {
+		frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+		ce.Caller = zapcore.NewEntryCaller(pc, frame.File, frame.Line, true)
+	}
+	zapFields := make([]zap.Field, 0, len(kvs)/2+1)
+	for i := 0; i < len(kvs); i += 2 {
+		if i+1 == len(kvs) {
+			zapFields = append(zapFields, zap.Any("!BADKEY", kvs[i]))
+			break
+		}
+		zapFields = append(zapFields, zap.Any(fmt.Sprint(kvs[i]), kvs[i+1]))
+	}
+	ce.Write(zapFields...)
+}
+
+// slogLogger 基于 *slog.Logger 的 core.Log 实现（NewSlogLogger 创建）。
+type slogLogger struct {
+	logger    *slog.Logger
+	traceName string
+	name      string // Named 名称（多级以 . 连接，以 logger 属性输出）
+	skip      int    // 额外跳过的调用栈层数（Skip）
+}
+
+// NewSlogLogger 创建基于 *slog.Logger 的 core.Log，可通过 SetLogger 作为容器的日志组件。
+// traceName 为追踪上下文在 ctx 中的 key（默认 X-Request-Id），日志带 trace_id / span_id 属性（规则同 ZapLogger）；
+// Named 名称以 logger 属性输出。
+func NewSlogLogger(logger *slog.Logger, traceName ...string) core.Log {
+	l := &slogLogger{logger: logger, traceName: "X-Request-Id"}
+	if len(traceName) > 0 {
+		l.traceName = traceName[0]
+	}
+	return l
+}
+
+func (l *slogLogger) BeanName() string {
+	return "log"
+}
+
+func (l *slogLogger) Named(named string) core.Log {
+	derived := *l
+	derived.name = named
+	if l.name != "" {
+		derived.name = l.name + "." + named
+	}
+	return &derived
+}
+
+func (l *slogLogger) Skip(skip int) core.Log {
+	derived := *l
+	if skip > 0 {
+		derived.skip += skip
+	}
+	return &derived
+}
+
+func (l *slogLogger) Logger() any {
+	return l.logger
+}
+
+func (l *slogLogger) Trace(ctx context.Context) context.Context {
+	if _, ok := traceContextFrom(ctx, l.traceName); l.traceName != "" && !ok {
+		ctx = context.WithValue(ctx, traceContextKey{name: l.traceName}, NewTraceContext())
+	}
+	return ctx
+}
+
+func (l *slogLogger) TraceWith(ctx context.Context, val any) context.Context {
+	if trace, ok := traceContextOf(val); ok && l.traceName != "" {
+		ctx = context.WithValue(ctx, traceContextKey{name: l.traceName}, trace)
+	}
+	return ctx
+}
+
+// log 构造 slog.Record 并交给 handler（调用位置跳过 log 与 Debug/Info 等方法两层，再加 Skip 指定的层数）。
+func (l *slogLogger) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if !l.logger.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(3+l.skip, pcs[:])
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	if l.name != "" {
+		r.AddAttrs(slog.String("logger", l.name))
+	}
+	if trace, ok := traceContextFrom(ctx, l.traceName); ok {
+		r.AddAttrs(slog.String(defaultTraceKey, trace.TraceID))
+		if trace.SpanID != "" {
+			r.AddAttrs(slog.String(defaultSpanKey, trace.SpanID))
+		}
+	}
+	r.Add(args...)
+	_ = l.logger.Handler().Handle(ctx, r)
+}
+
+func (l *slogLogger) logw(ctx context.Context, level slog.Level, msg string, keyAndValues []map[string]any) {
+	var args []any
+	for _, keyAndValue := range keyAndValues {
+		for key, value := range keyAndValue {
+			args = append(args, key, value)
+		}
+	}
+	// 多一层调用栈（logw）
+	l.Skip(1).(*slogLogger).log(ctx, level, msg, args...)
+}
+
+func (l *slogLogger) Debug(ctx context.Context, msg string, keyAndValues ...any) {
+	l.log(ctx, slog.LevelDebug, msg, keyAndValues...)
+}
+
+func (l *slogLogger) Info(ctx context.Context, msg string, keyAndValues ...any) {
+	l.log(ctx, slog.LevelInfo, msg, keyAndValues...)
+}
+
+func (l *slogLogger) Warn(ctx context.Context, msg string, keyAndValues ...any) {
+	l.log(ctx, slog.LevelWarn, msg, keyAndValues...)
+}
+
+func (l *slogLogger) Error(ctx context.Context, msg string, keyAndValues ...any) {
+	l.log(ctx, slog.LevelError, msg, keyAndValues...)
+}
+
+func (l *slogLogger) Debugw(ctx context.Context, msg string, keyAndValues ...map[string]any) {
+	l.logw(ctx, slog.LevelDebug, msg, keyAndValues)
+}
+
+func (l *slogLogger) Infow(ctx context.Context, msg string, keyAndValues ...map[string]any) {
+	l.logw(ctx, slog.LevelInfo, msg, keyAndValues)
+}
+
+func (l *slogLogger) Warnw(ctx context.Context, msg string, keyAndValues ...map[string]any) {
+	l.logw(ctx, slog.LevelWarn, msg, keyAndValues)
+}
+
+func (l *slogLogger) Errorw(ctx context.Context, msg string, keyAndValues ...map[string]any) {
+	l.logw(ctx, slog.LevelError, msg, keyAndValues)
+}
+
+// setDefaultSlog 在 log.slog-default=true 时将容器日志组件设置为 slog 默认 logger（slog.SetDefault），
+// 原默认 logger 在停机（bean 销毁、日志组件关闭）后由 restoreDefaultSlog 还原。
+// 日志组件本身基于 slog（NewSlogLogger）时跳过，避免 slog 默认 handler 与日志组件互相转发。
+func (d *dioContainer) setDefaultSlog() {
+	if enabled := d.GetPropertyString("log.slog-default"); enabled != "true" {
+		return
+	}
+	if _, ok := d.log.(*slogLogger); ok {
+		return
+	}
+	d.mu.Lock()
+	if d.previousSlog == nil {
+		d.previousSlog = slog.Default()
+	}
+	d.mu.Unlock()
+	slog.SetDefault(slog.New(NewSlogHandler(d.log)))
+}
+
+// restoreDefaultSlog 还原 setDefaultSlog 替换前的 slog 默认 logger（未替换时为空操作）。
+func (d *dioContainer) restoreDefaultSlog() {
+	d.mu.Lock()
+	previous := d.previousSlog
+	d.previousSlog = nil
+	d.mu.Unlock()
+	if previous != nil {
+		slog.SetDefault(previous)
+	}
+}
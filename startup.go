@@ -0,0 +1,368 @@
+package dio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// 启动耗时报告默认值：可通过 startup.slow-threshold / startup.report-top 配置覆盖。
+const (
+	defaultSlowBeanThreshold = time.Second
+	defaultStartupReportTop  = 5
+	startupSampleInterval    = time.Millisecond // Load 期间采样 bean 生命周期回调的间隔
+)
+
+// PhaseTiming 启动阶段耗时。
+// 阶段依次为 prepare（banner/必填配置校验）、logger（日志组件创建）、register（bean 注册）、
+// load（di 容器加载：实例化、注入与生命周期回调）、after-run（afterRunFns 执行）。
+type PhaseTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// BeanTiming 单个 bean 的启动耗时。
+// 注册与工厂函数（ProvideFunc）的执行逐次计时；BeanConstruct / AfterPropertiesSet 回调由 di 在 Load 内部调用、
+// 没有逐 bean 的钩子，按 Load 期间的调用栈采样估算（精度约 1ms，足以定位慢 bean）。
+// aware/value 注入本身只是字段赋值，耗时可忽略；注入时创建依赖 bean 的耗时计入被创建的 bean。
+type BeanTiming struct {
+	Name      string
+	Register  time.Duration // 注册耗时（注册进 di 容器，含 bean 定义解析）
+	Construct time.Duration // 构造耗时（ProvideFunc 工厂函数执行与 BeanConstruct 回调）
+	Init      time.Duration // 初始化耗时（AfterPropertiesSet 回调）
+}
+
+// Total 返回 bean 的总启动耗时。
+func (t BeanTiming) Total() time.Duration {
+	return t.Register + t.Construct + t.Init
+}
+
+// StartupTiming 启动耗时报告。
+type StartupTiming struct {
+	Total  time.Duration // Run 开始到进入 Running 的总耗时（未完成启动时为 0）
+	Phases []PhaseTiming // 各启动阶段耗时（按执行顺序）
+	Beans  []BeanTiming  // 逐 bean 耗时（按总耗时降序）
+}
+
+// Slowest 返回耗时最高的 n 个 bean。
+func (r StartupTiming) Slowest(n int) []BeanTiming {
+	if n > len(r.Beans) {
+		n = len(r.Beans)
+	}
+	if n <= 0 {
+		return nil
+	}
+	return r.Beans[:n]
+}
+
+// StartupReport 返回最近一次 Run 的启动耗时报告。
+func (d *dioContainer) StartupReport() StartupTiming {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	report := StartupTiming{
+		Total:  d.startupTotal,
+		Phases: append([]PhaseTiming(nil), d.startupPhases...),
+		Beans:  make([]BeanTiming, 0, len(d.beanTimings)),
+	}
+	for _, timing := range d.beanTimings {
+		report.Beans = append(report.Beans, *timing)
+	}
+	sort.Slice(report.Beans, func(i, j int) bool {
+		if report.Beans[i].Total() != report.Beans[j].Total() {
+			return report.Beans[i].Total() > report.Beans[j].Total()
+		}
+		return report.Beans[i].Name < report.Beans[j].Name
+	})
+	return report
+}
+
+// endPhase 记录一个启动阶段的耗时，返回下一阶段的起点。
+func (d *dioContainer) endPhase(name string, start time.Time) time.Time {
+	now := time.Now()
+	d.mu.Lock()
+	d.startupPhases = append(d.startupPhases, PhaseTiming{Name: name, Duration: now.Sub(start)})
+	d.mu.Unlock()
+	return now
+}
+
+// beanTiming 返回（必要时创建）bean 的耗时记录，调用方需持有 d.mu。
+func (d *dioContainer) beanTiming(name string) *BeanTiming {
+	if d.beanTimings == nil {
+		d.beanTimings = map[string]*BeanTiming{}
+	}
+	timing, ok := d.beanTimings[name]
+	if !ok {
+		timing = &BeanTiming{Name: name}
+		d.beanTimings[name] = timing
+	}
+	return timing
+}
+
+// timedRegister 执行一次 bean 注册并记录注册耗时，返回 bean 名称（name 为空时按 di 的规则推断）。
+func (d *dioContainer) timedRegister(name string, instance any, register func()) string {
+	name = inferBeanName(name, instance)
+	start := time.Now()
+	register()
+	cost := time.Since(start)
+	if name != "" {
+		d.mu.Lock()
+		d.beanTiming(name).Register += cost
+		d.addBeanType(reflect.TypeOf(instance), name)
+		d.mu.Unlock()
+	}
+	return name
+}
+
+// addBeanType 记录 bean 类型对应的名称（采样回调耗时时按调用栈中的接收者类型归属 bean），调用方需持有 d.mu。
+func (d *dioContainer) addBeanType(t reflect.Type, name string) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Name() == "" {
+		return
+	}
+	if d.beanTypes == nil {
+		d.beanTypes = map[string]string{}
+	}
+	d.beanTypes[t.PkgPath()+"."+t.Name()] = name
+}
+
+// inferBeanName 按 di 的规则推断 bean 名称：显式名称优先，其次 BeanName 方法，否则为类型名首字母小写。
+func inferBeanName(name string, instance any) string {
+	if name != "" {
+		return name
+	}
+	if named, ok := instance.(interface{ BeanName() string }); ok {
+		return named.BeanName()
+	}
+	t := reflect.TypeOf(instance)
+	if t == nil {
+		return ""
+	}
+	if t.Kind() != reflect.Pointer {
+		// 值形式的原型：BeanName 可能定义在指针接收者上
+		ptr := reflect.New(t)
+		ptr.Elem().Set(reflect.ValueOf(instance))
+		if named, ok := ptr.Interface().(interface{ BeanName() string }); ok {
+			return named.BeanName()
+		}
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Name() == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(t.Name())
+	return string(unicode.ToLower(r)) + t.Name()[size:]
+}
+
+// timedFactory 包装工厂函数以记录其执行耗时（函数签名保持不变，di 的参数/返回值推断不受影响）。
+// bean 名称由工厂返回的实例推断；注册耗时在注册完成后写入 registerCost，首次执行时一并计入。
+func (d *dioContainer) timedFactory(fn any, registerCost *time.Duration) any {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func {
+		return fn
+	}
+	return reflect.MakeFunc(fnValue.Type(), func(args []reflect.Value) []reflect.Value {
+		start := time.Now()
+		var results []reflect.Value
+		if fnValue.Type().IsVariadic() {
+			results = fnValue.CallSlice(args)
+		} else {
+			results = fnValue.Call(args)
+		}
+		cost := time.Since(start)
+		if len(results) == 0 || !results[0].IsValid() {
+			return results
+		}
+		if name := inferBeanName("", results[0].Interface()); name != "" {
+			d.mu.Lock()
+			timing := d.beanTiming(name)
+			timing.Register += *registerCost
+			timing.Construct += cost
+			*registerCost = 0
+			d.addBeanType(results[0].Type(), name)
+			d.addFactoryInputs(name, fnValue.Type())
+			d.mu.Unlock()
+		}
+		return results
+	}).Interface()
+}
+
+// addFactoryInputs 记录工厂 bean 的入参类型（DescribeBeans 按类型解析依赖），调用方需持有 d.mu。
+func (d *dioContainer) addFactoryInputs(name string, fnType reflect.Type) {
+	if d.factoryInputs == nil {
+		d.factoryInputs = map[string][]reflect.Type{}
+	}
+	inputs := make([]reflect.Type, 0, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		if fnType.IsVariadic() && i == fnType.NumIn()-1 {
+			break
+		}
+		inputs = append(inputs, fnType.In(i))
+	}
+	d.factoryInputs[name] = inputs
+}
+
+// sampleBeanCallbacks 在 Load 期间按 startupSampleInterval 采样调用方 goroutine 的调用栈，
+// 停留在 bean 的 BeanConstruct / AfterPropertiesSet 回调中的时间计入该 bean 的 Construct / Init（嵌套时归属最内层回调）。
+// 返回的 stop 函数结束采样并等待采样 goroutine 退出（在 Load 返回后调用）。
+func (d *dioContainer) sampleBeanCallbacks() (stop func()) {
+	id := goroutineID()
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(startupSampleInterval)
+		defer ticker.Stop()
+		buf := make([]byte, 64<<10)
+		last := time.Now()
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				buf = allStacks(buf)
+				d.mu.Lock()
+				if name, method, ok := beanCallbackFrame(goroutineStack(buf, id), d.beanTypes); ok {
+					if method == "AfterPropertiesSet" {
+						d.beanTiming(name).Init += now.Sub(last)
+					} else {
+						d.beanTiming(name).Construct += now.Sub(last)
+					}
+				}
+				d.mu.Unlock()
+				last = now
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-exited
+	}
+}
+
+// goroutineID 返回当前 goroutine 的 id（解析 runtime.Stack 的首行 "goroutine N [...]"）。
+func goroutineID() string {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	fields := strings.Fields(string(buf))
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[1]
+}
+
+// allStacks 返回所有 goroutine 的调用栈，buf 不足时扩容。
+func allStacks(buf []byte) []byte {
+	buf = buf[:cap(buf)]
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
+
+// goroutineStack 从全部调用栈中截取指定 goroutine 的部分。
+func goroutineStack(stacks []byte, id string) []byte {
+	header := []byte("goroutine " + id + " [")
+	start := bytes.Index(stacks, header)
+	if id == "" || start < 0 {
+		return nil
+	}
+	stack := stacks[start:]
+	if end := bytes.Index(stack, []byte("\n\n")); end >= 0 {
+		stack = stack[:end]
+	}
+	return stack
+}
+
+// beanCallbackFrame 自栈顶向下查找第一个 bean 生命周期回调帧（如 pkg.(*T).AfterPropertiesSet(...)），
+// 返回接收者类型对应的 bean 名称与方法名。
+func beanCallbackFrame(stack []byte, types map[string]string) (name, method string, ok bool) {
+	for _, line := range strings.Split(string(stack), "\n") {
+		if line == "" || line[0] == '\t' {
+			continue
+		}
+		fn := line
+		if i := strings.LastIndexByte(fn, '('); i > 0 {
+			fn = fn[:i]
+		}
+		dot := strings.LastIndexByte(fn, '.')
+		if dot < 0 {
+			continue
+		}
+		method = fn[dot+1:]
+		if method != "BeanConstruct" && method != "AfterPropertiesSet" {
+			continue
+		}
+		receiver := strings.NewReplacer("(*", "", ")", "").Replace(fn[:dot])
+		if i := strings.IndexByte(receiver, '['); i >= 0 {
+			receiver = receiver[:i]
+		}
+		if name, ok = types[receiver]; ok {
+			return name, method, true
+		}
+	}
+	return "", "", false
+}
+
+// logStartupReport 输出最慢的若干 bean，并对超过阈值的 bean 输出警告。
+// 阈值与数量分别由 startup.slow-threshold（如 500ms，纯数字按毫秒计）与 startup.report-top（0 关闭）配置。
+func (d *dioContainer) logStartupReport(ctx context.Context) {
+	report := d.StartupReport()
+	threshold := d.durationProperty("startup.slow-threshold", defaultSlowBeanThreshold)
+	top := defaultStartupReportTop
+	if v := d.GetPropertyString("startup.report-top"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			top = n
+		}
+	}
+	if slowest := report.Slowest(top); len(slowest) > 0 {
+		parts := make([]string, 0, len(slowest))
+		for _, timing := range slowest {
+			parts = append(parts, fmt.Sprintf("%s=%s", timing.Name, timing.Total()))
+		}
+		phases := make([]string, 0, len(report.Phases))
+		for _, phase := range report.Phases {
+			phases = append(phases, fmt.Sprintf("%s=%s", phase.Name, phase.Duration))
+		}
+		d.log.Info(ctx, fmt.Sprintf("startup phases: %s; slowest beans: %s", strings.Join(phases, ", "), strings.Join(parts, ", ")))
+	}
+	if threshold <= 0 {
+		return
+	}
+	for _, timing := range report.Beans {
+		if timing.Total() <= threshold {
+			break
+		}
+		d.log.Warn(ctx, fmt.Sprintf("bean %s took %s to start, exceeds threshold %s", timing.Name, timing.Total(), threshold))
+	}
+}
+
+// durationProperty 读取时长配置：支持 time.ParseDuration 格式（如 500ms/2s），纯数字按毫秒计；
+// 未配置或格式错误时返回默认值。
+func (d *dioContainer) durationProperty(key string, defaultValue time.Duration) time.Duration {
+	v := d.GetPropertyString(key)
+	if v == "" {
+		return defaultValue
+	}
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return time.Duration(ms) * time.Millisecond
+	}
+	if duration, err := time.ParseDuration(v); err == nil {
+		return duration
+	}
+	return defaultValue
+}
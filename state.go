@@ -0,0 +1,85 @@
+package dio
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// StateTransition 一次应用状态变更记录。
+type StateTransition struct {
+	From     AppState      // 变更前状态
+	To       AppState      // 变更后状态
+	Time     time.Time     // 变更时间
+	Duration time.Duration // 在 From 状态停留的时长（首次变更从容器创建开始计算）
+	Err      error         // To 为 Failed 时的失败原因
+
+	silent bool // 未经 setState 记录（Run 失败时），不通知已注册的回调，仅在之后注册的回调重放时投递
+}
+
+func (t StateTransition) String() string {
+	s := fmt.Sprintf("%s %s -> %s (+%s)", t.Time.Format("2006-01-02 15:04:05.000"), t.From, t.To, t.Duration)
+	if t.Err != nil {
+		s += ": " + t.Err.Error()
+	}
+	return s
+}
+
+// recordTransition 追加一条状态变更记录，调用方需持有 d.mu。
+func (d *dioContainer) recordTransition(from, to AppState, err error) {
+	now := time.Now()
+	last := d.createTime
+	if n := len(d.stateHistory); n > 0 {
+		last = d.stateHistory[n-1].Time
+	}
+	d.stateHistory = append(d.stateHistory, StateTransition{From: from, To: to, Time: now, Duration: now.Sub(last), Err: err})
+}
+
+// StateHistory 返回全部状态变更记录（按时间先后），可用于输出启动/停机时间线。
+// 失败重试（Failed→Starting）不清空历史，整个容器生命周期的变更都会保留。
+func (d *dioContainer) StateHistory() []StateTransition {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]StateTransition(nil), d.stateHistory...)
+}
+
+// stateListener 状态变更回调及其投递进度，保证同一回调按状态历史顺序逐条收到通知。
+type stateListener struct {
+	fn       func(AppState)
+	mu       sync.Mutex // 投递中持有；并发或重入的投递抢不到时由持有者补齐
+	next     int        // 下一条待投递的状态历史下标，受 d.mu 保护
+	replayTo int        // 注册时的状态历史长度：此前的记录全部重放，此后仅投递 setState 推进的状态
+}
+
+// deliverState 将 listener 尚未收到的状态变更按顺序投递给它。
+// 已有其他 goroutine（或外层调用）在投递时直接返回，由持有者补齐；释放后再检查一次，避免遗漏释放前追加的记录。
+func (d *dioContainer) deliverState(listener *stateListener) {
+	for listener.mu.TryLock() {
+		d.drainState(listener)
+		d.mu.Lock()
+		pending := listener.next < len(d.stateHistory)
+		d.mu.Unlock()
+		if !pending {
+			return
+		}
+	}
+}
+
+// drainState 在持有 listener.mu 时投递全部待投递记录，回调 panic 时同样释放 listener.mu。
+func (d *dioContainer) drainState(listener *stateListener) {
+	defer listener.mu.Unlock()
+	for {
+		d.mu.Lock()
+		if listener.next >= len(d.stateHistory) {
+			d.mu.Unlock()
+			return
+		}
+		i := listener.next
+		t := d.stateHistory[i]
+		listener.next++
+		d.mu.Unlock()
+		if i < listener.replayTo || !t.silent {
+			listener.fn(t.To)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio/diotest"
+)
+
+// TestBannerFileLogMode 验证从 fs.FS 加载 banner 模板并以 log 模式输出到日志。
+func TestBannerFileLogMode(t *testing.T) {
+	defer dio.Reset()
+	capture := diotest.NewCaptureLogger()
+	files := fstest.MapFS{"banner.txt": {Data: []byte("{{.AppName}} {{.AppVersion}} [{{.Profile}}] {{.GoVersion}} pid={{.PID}}\n")}}
+	dio.SetBannerFile(files, "banner.txt")
+	dio.SetBannerMode(dio.BannerModeLog)
+	dio.SetProfile("dev").
+		SetProperty("app.name", "demo").
+		SetProperty("app.version", "1.2.0").
+		SetLogger(capture)
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+
+	want := "demo 1.2.0 [dev] " + runtime.Version() + " pid=" + strconv.Itoa(os.Getpid())
+	capture.AssertLogged(t, diotest.LevelInfo, want)
+}
+
+// TestBannerProperty 验证 banner.text 配置优先于 SetBanner，banner.mode=off 关闭输出。
+func TestBannerProperty(t *testing.T) {
+	defer dio.Reset()
+	capture := diotest.NewCaptureLogger()
+	dio.SetBanner("code banner").
+		SetProperty("banner.text", "property banner {{.AppName}}").
+		SetProperty("banner.mode", "log").
+		SetProperty("app.name", "demo").
+		SetLogger(capture)
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	capture.AssertLogged(t, diotest.LevelInfo, "property banner demo")
+	capture.AssertNotLogged(t, "", "code banner")
+
+	dio.Reset()
+	capture = diotest.NewCaptureLogger()
+	dio.SetBannerMode(dio.BannerModeLog)
+	dio.SetProperty("banner.mode", dio.BannerModeOff).SetLogger(capture)
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	if entries := capture.Filter(func(e diotest.Entry) bool { return strings.Contains(e.Message, `\/___/`) }); len(entries) > 0 {
+		t.Fatalf("banner should be off: %v", entries)
+	}
+}
+
+// TestBannerFileMissing 验证 banner 文件读取失败时 Run 启动失败。
+func TestBannerFileMissing(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBannerFile(fstest.MapFS{}, "banner.txt")
+	runWithTimeout(t, func() {
+		dio.Run(context.Background())
+	})
+	if dio.State() != dio.Failed || !errors.Is(dio.FailureCause(), fs.ErrNotExist) {
+		t.Fatalf("state = %v, cause = %v, want Failed with fs.ErrNotExist", dio.State(), dio.FailureCause())
+	}
+}
+
+// TestBannerRawText 验证 SetBanner 的文本原样输出，其中的 {{ 不按模板解析。
+func TestBannerRawText(t *testing.T) {
+	defer dio.Reset()
+	capture := diotest.NewCaptureLogger()
+	dio.SetBanner(" {{ dio }} ")
+	dio.SetBannerMode(dio.BannerModeLog)
+	dio.SetLogger(capture)
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	capture.AssertLogged(t, diotest.LevelInfo, " {{ dio }} ")
+}
+
+// TestBannerInvalidMode 验证未知的 banner.mode 取值使 Run 启动失败。
+func TestBannerInvalidMode(t *testing.T) {
+	defer dio.Reset()
+	dio.SetProperty("banner.mode", "stdout")
+	runWithTimeout(t, func() {
+		dio.Run(context.Background())
+	})
+	if dio.State() != dio.Failed || dio.FailureCause() == nil || !strings.Contains(dio.FailureCause().Error(), "banner mode") {
+		t.Fatalf("state = %v, cause = %v, want Failed with invalid banner mode", dio.State(), dio.FailureCause())
+	}
+}
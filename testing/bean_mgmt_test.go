@@ -2,6 +2,7 @@ package testing
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -68,3 +69,94 @@ func TestBeanManagement(t *testing.T) {
 		t.Fatal("GetBeanDependencies should return ok=false for missing bean")
 	}
 }
+
+type mgmtCache struct{}
+
+type mgmtInstance struct{}
+
+// TestDescribeBeans 验证 bean 管理视图：注册形态、载入条件、依赖与被依赖，以及 DOT/Mermaid 导出。
+func TestDescribeBeans(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("cache.type", "redis")
+	dio.Provide(mgmtService{}, mgmtDep{})
+	dio.ProvideOnProperty(mgmtCache{}, "cache.type", "redis")
+	dio.RegisterBean(&mgmtInstance{})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+
+	graph := dio.DescribeBeans()
+	beans := map[string]dio.BeanInfo{}
+	for _, info := range graph.Beans {
+		beans[info.Name] = info
+	}
+	if s := beans["mgmtService"]; s.Kind != dio.BeanKindPrototype || len(s.Dependencies) != 1 || s.Dependencies[0] != "mgmtDep" {
+		t.Fatalf("unexpected mgmtService info: %+v", s)
+	}
+	if dep := beans["mgmtDep"]; len(dep.Dependents) != 1 || dep.Dependents[0] != "mgmtService" {
+		t.Fatalf("mgmtDep dependents = %v, want [mgmtService]", dep.Dependents)
+	}
+	if c := beans["mgmtCache"]; !strings.HasPrefix(c.Condition, "cache.type=redis") {
+		t.Fatalf("mgmtCache condition = %q, want cache.type=redis", c.Condition)
+	}
+	if i := beans["mgmtInstance"]; i.Kind != dio.BeanKindInstance || i.Type != "*testing.mgmtInstance" {
+		t.Fatalf("unexpected mgmtInstance info: %+v", i)
+	}
+
+	if dot := graph.DOT(); !strings.Contains(dot, `"mgmtService" -> "mgmtDep";`) {
+		t.Fatalf("DOT should contain mgmtService -> mgmtDep edge:\n%s", dot)
+	}
+	if mermaid := graph.Mermaid(); !strings.HasPrefix(mermaid, "flowchart LR") || !strings.Contains(mermaid, "-->") {
+		t.Fatalf("unexpected mermaid output:\n%s", mermaid)
+	}
+}
+
+// mgmtStore 按接口类型注入的依赖
+type mgmtStore interface {
+	Load(key string) string
+}
+
+type mgmtMemStore struct{}
+
+func (*mgmtMemStore) Load(key string) string { return key }
+
+// mgmtHandler 未命名 aware 字段按类型注入（接口与指针）
+type mgmtHandler struct {
+	Store mgmtStore `aware:""`
+	Dep   *mgmtDep  `aware:""`
+}
+
+type mgmtClient struct{}
+
+// TestDescribeBeansTypedDependencies 验证未命名 aware 字段与工厂函数入参按类型解析为依赖，并生成反向的被依赖关系。
+func TestDescribeBeansTypedDependencies(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.Provide(mgmtHandler{}, mgmtDep{}, mgmtMemStore{})
+	dio.ProvideFunc(func(store mgmtStore) *mgmtClient { return &mgmtClient{} })
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+
+	beans := map[string]dio.BeanInfo{}
+	for _, info := range dio.DescribeBeans().Beans {
+		beans[info.Name] = info
+	}
+	if deps := beans["mgmtHandler"].Dependencies; strings.Join(deps, ",") != "mgmtDep,mgmtMemStore" {
+		t.Fatalf("mgmtHandler dependencies = %v, want [mgmtDep mgmtMemStore]", deps)
+	}
+	if deps := beans["mgmtClient"].Dependencies; strings.Join(deps, ",") != "mgmtMemStore" {
+		t.Fatalf("mgmtClient dependencies = %v, want [mgmtMemStore]", deps)
+	}
+	if dependents := beans["mgmtMemStore"].Dependents; strings.Join(dependents, ",") != "mgmtClient,mgmtHandler" {
+		t.Fatalf("mgmtMemStore dependents = %v, want [mgmtClient mgmtHandler]", dependents)
+	}
+	if dependents := beans["mgmtDep"].Dependents; strings.Join(dependents, ",") != "mgmtHandler" {
+		t.Fatalf("mgmtDep dependents = %v, want [mgmtHandler]", dependents)
+	}
+}
@@ -1,7 +1,10 @@
 package testing
 
 import (
+	"context"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/cheivin/dio"
 )
@@ -27,3 +30,68 @@ func TestLoadConfigDir(t *testing.T) {
 		t.Fatalf("app.port = %q, want 9999 (explicit Set should win)", v)
 	}
 }
+
+// TestReloadConfig 验证 ReloadConfig 按原加载顺序重新读取配置文件，显式 Set 的非文件配置项不受影响。
+func TestReloadConfig(t *testing.T) {
+	defer dio.Reset()
+	configFS := fstest.MapFS{
+		"config.yaml": {Data: []byte("app:\n  name: v1\n")},
+	}
+	dio.LoadConfig(configFS, "config.yaml")
+	dio.SetProperty("app.port", 9000)
+	if v := dio.GetPropertyString("app.name"); v != "v1" {
+		t.Fatalf("app.name = %q, want v1", v)
+	}
+
+	configFS["config.yaml"] = &fstest.MapFile{Data: []byte("app:\n  name: v2\n")}
+	if err := dio.ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig should succeed, got %v", err)
+	}
+	if v := dio.GetPropertyString("app.name"); v != "v2" {
+		t.Fatalf("app.name after reload = %q, want v2", v)
+	}
+	if v := dio.GetPropertyString("app.port"); v != "9000" {
+		t.Fatalf("app.port after reload = %q, want 9000 (explicit Set should be kept)", v)
+	}
+
+	// 来源读取失败返回错误
+	delete(configFS, "config.yaml")
+	if err := dio.ReloadConfig(); err == nil {
+		t.Fatal("ReloadConfig should fail when config file is removed")
+	}
+}
+
+// TestRestartRereadsConfig 验证 Restart 重建容器时重新读取一次配置文件（而非重放历次 ReloadConfig 的结果）。
+func TestRestartRereadsConfig(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	configFS := fstest.MapFS{
+		"config.yaml": {Data: []byte("app:\n  name: v1\n")},
+	}
+	dio.LoadConfig(configFS, "config.yaml")
+	dio.SetProperty("app.port", 9000)
+	run := func(fn func(ctx context.Context)) {
+		runWithTimeout(t, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			fn(ctx)
+		})
+	}
+	run(dio.Run)
+
+	configFS["config.yaml"] = &fstest.MapFile{Data: []byte("app:\n  name: v2\n")}
+	for i := 0; i < 3; i++ {
+		if err := dio.ReloadConfig(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	configFS["config.yaml"] = &fstest.MapFile{Data: []byte("app:\n  name: v3\n")}
+	run(dio.Restart)
+
+	if v := dio.GetPropertyString("app.name"); v != "v3" {
+		t.Fatalf("app.name after Restart = %q, want v3 (config file re-read)", v)
+	}
+	if v := dio.GetPropertyString("app.port"); v != "9000" {
+		t.Fatalf("app.port after Restart = %q, want 9000 (explicit Set replayed)", v)
+	}
+}
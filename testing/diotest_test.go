@@ -0,0 +1,90 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio/diotest"
+)
+
+// TestCaptureLogger 验证捕获日志的级别、字段、Named 名称与 trace id，以及查询方法。
+func TestCaptureLogger(t *testing.T) {
+	capture := diotest.NewCaptureLogger()
+	ctx := capture.TraceWith(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	capture.Info(ctx, "order paid", "orderId", 1001, "amount", 9.9)
+	db := capture.Named("db")
+	db.Named("pool").Warnw(context.Background(), "pool exhausted", map[string]any{"size": 10})
+	db.Error(context.Background(), "query failed", "sql")
+
+	entry := capture.AssertLogged(t, diotest.LevelInfo, "order paid", "orderId", 1001)
+	if entry.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || entry.Fields["amount"] != 9.9 {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if entries := capture.ByLogger("db"); len(entries) != 2 || entries[0].Logger != "db.pool" || entries[0].Fields["size"] != 10 {
+		t.Fatalf("unexpected db entries: %v", entries)
+	}
+	if entries := capture.ByLevel(diotest.LevelError); len(entries) != 1 || entries[0].Fields["!BADKEY"] != "sql" {
+		t.Fatalf("unexpected error entries: %v", entries)
+	}
+	if entries := capture.ByTrace("4bf92f3577b34da6a3ce929d0e0e4736"); len(entries) != 1 {
+		t.Fatalf("unexpected trace entries: %v", entries)
+	}
+	if _, ok := capture.Find(diotest.LevelInfo, "order paid", "orderId", 1002); ok {
+		t.Fatal("field value mismatch should not match")
+	}
+	capture.AssertNotLogged(t, diotest.LevelWarn, "order paid")
+
+	traced := capture.Trace(context.Background())
+	if capture.Trace(traced) != traced {
+		t.Fatal("Trace should keep the existing trace context")
+	}
+	capture.Reset()
+	capture.AssertNoErrors(t)
+	if len(capture.Entries()) != 0 {
+		t.Fatal("Reset should clear entries")
+	}
+}
+
+// TestCaptureLoggerAssertFailure 验证断言失败时输出已捕获的日志。
+func TestCaptureLoggerAssertFailure(t *testing.T) {
+	capture := diotest.NewCaptureLogger()
+	capture.Error(context.Background(), "boom", "code", 500)
+	rec := &recordTB{TB: t}
+	capture.AssertLogged(rec, diotest.LevelInfo, "missing")
+	capture.AssertNoErrors(rec)
+	if len(rec.errors) != 2 || !strings.Contains(rec.errors[0], "error boom map[code:500]") {
+		t.Fatalf("unexpected assertion errors: %q", rec.errors)
+	}
+}
+
+// recordTB 记录 Errorf 而不使测试失败。
+type recordTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordTB) Helper() {}
+
+func (r *recordTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+// TestCaptureLoggerContainer 验证通过 SetLogger 安装后可断言容器输出的日志。
+func TestCaptureLoggerContainer(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	capture := diotest.NewCaptureLogger()
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.SetLogger(capture).Run(ctx)
+	})
+	entries := capture.Filter(func(e diotest.Entry) bool { return strings.HasPrefix(e.Message, "started in ") })
+	if len(entries) != 1 || entries[0].Level != diotest.LevelInfo {
+		t.Fatalf("startup summary not captured: %v", capture.Entries())
+	}
+}
@@ -0,0 +1,133 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheivin/di"
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio/diotest"
+)
+
+// TestFailureCause 验证启动失败时 FailureCause 保留原始错误，修正后重试成功则清空。
+func TestFailureCause(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.RequireProperties("app.port")
+	if err := dio.FailureCause(); err != nil {
+		t.Fatalf("FailureCause before Run should be nil, got %v", err)
+	}
+
+	runWithTimeout(t, func() {
+		dio.Run(context.Background())
+	})
+	if dio.State() != dio.Failed {
+		t.Fatalf("state should be Failed, got %s", dio.State())
+	}
+	if err := dio.FailureCause(); !errors.Is(err, dio.ErrMissingProperty) {
+		t.Fatalf("FailureCause should be ErrMissingProperty, got %v", err)
+	}
+
+	dio.SetProperty("app.port", 8080)
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	if dio.State() != dio.Stopped {
+		t.Fatalf("after retry, state should be Stopped, got %s", dio.State())
+	}
+	if err := dio.FailureCause(); err != nil {
+		t.Fatalf("FailureCause after successful retry should be nil, got %v", err)
+	}
+}
+
+// TestFailureAnalyzer 验证自定义分析器优先于内置分析器，且收到原始错误。
+func TestFailureAnalyzer(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.RequireProperties("db.host")
+	var analyzed error
+	dio.AddFailureAnalyzer(dio.FailureAnalyzerFunc(func(cause error) *dio.FailureAnalysis {
+		analyzed = cause
+		if !errors.Is(cause, dio.ErrMissingProperty) {
+			return nil
+		}
+		return &dio.FailureAnalysis{Description: "db not configured", Action: "set db.host"}
+	}))
+
+	runWithTimeout(t, func() {
+		dio.Run(context.Background())
+	})
+	if !errors.Is(analyzed, dio.ErrMissingProperty) {
+		t.Fatalf("custom analyzer should receive ErrMissingProperty, got %v", analyzed)
+	}
+}
+
+// TestFailureAnalysisString 验证分析结果的输出格式包含描述与建议动作。
+func TestFailureAnalysisString(t *testing.T) {
+	s := dio.FailureAnalysis{Description: "something broken", Action: "fix it"}.String()
+	if !strings.Contains(s, "APPLICATION FAILED TO START") ||
+		!strings.Contains(s, "Description:\n\nsomething broken") ||
+		!strings.Contains(s, "Action:\n\nfix it") {
+		t.Fatalf("unexpected analysis output: %q", s)
+	}
+}
+
+// TestBuiltinFailureAnalyzers 验证内置分析器按 di 错误哨兵识别循环依赖与 aware 注入失败，不误判仅含相似单词的错误。
+func TestBuiltinFailureAnalyzers(t *testing.T) {
+	const (
+		circular = "A circular dependency between beans was detected"
+		aware    = "A bean required by an aware field could not be resolved"
+	)
+	tests := []struct {
+		name string
+		err  error
+		want string // 期望输出的描述前缀，为空表示不应被内置分析器识别
+	}{
+		{"di circular", fmt.Errorf("%w: circular reference orderService -> userService -> orderService", di.ErrBean), circular},
+		{"di definition circular", fmt.Errorf("%w: Circular check failed for orderService", di.ErrDefinition), circular},
+		{"plain circular dependency", errors.New("circular dependency detected: a -> b -> a"), circular},
+		{"di aware", fmt.Errorf("%w: aware field OrderService.Repo cannot be resolved", di.ErrBean), aware},
+		{"di bean not found", fmt.Errorf("%w: bean userRepository not found", di.ErrBean), aware},
+		{"plain aware tag", errors.New("invalid aware tag on OrderService.Repo"), aware},
+		{"software error", errors.New("software error: non-circularity check failed"), ""},
+		{"unaware", errors.New("client unaware of the semicircular layout"), ""},
+		{"plain not found", errors.New("config file not found"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer dio.Reset()
+			dio.SetBanner("")
+			capture := diotest.NewCaptureLogger()
+			dio.OnStateChange(func(s dio.AppState) {
+				if s == dio.Starting {
+					panic(tt.err)
+				}
+			})
+			dio.SetLogger(capture)
+			runWithTimeout(t, func() {
+				dio.Run(context.Background())
+			})
+			if !errors.Is(dio.FailureCause(), tt.err) {
+				t.Fatalf("FailureCause = %v, want %v", dio.FailureCause(), tt.err)
+			}
+			analyses := capture.Filter(func(e diotest.Entry) bool {
+				return strings.Contains(e.Message, "APPLICATION FAILED TO START")
+			})
+			if tt.want == "" {
+				if len(analyses) != 0 {
+					t.Fatalf("error should not be analyzed: %v", analyses)
+				}
+				return
+			}
+			if len(analyses) != 1 || !strings.Contains(analyses[0].Message, tt.want) {
+				t.Fatalf("analysis = %v, want %q", analyses, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package testing
 import (
 	"context"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -52,8 +53,8 @@ func runAndHealth(t *testing.T) error {
 // TestHealthNotReady 验证未就绪（未 Run 或非 Running）时 Health 直接返回错误。
 func TestHealthNotReady(t *testing.T) {
 	defer dio.Reset()
-	if err := dio.Health(context.Background()); err == nil {
-		t.Fatal("Health before Run should return error")
+	if err := dio.Health(context.Background()); !errors.Is(err, dio.ErrNotReady) {
+		t.Fatalf("Health before Run should return ErrNotReady, got %v", err)
 	}
 }
 
@@ -107,3 +108,359 @@ func TestHealthTimeout(t *testing.T) {
 		t.Fatalf("Health error should contain DeadlineExceeded, got %v", healthErr)
 	}
 }
+
+// checkDegraded 通过 HealthDetailer 返回降级状态与详情
+type checkDegraded struct{}
+
+func (checkDegraded) HealthDetails(ctx context.Context) (dio.HealthStatus, map[string]any, error) {
+	return dio.StatusDegraded, map[string]any{"replicas": 1}, nil
+}
+
+// runAndCheckHealth 在容器 Running 状态下执行 CheckHealth 并返回报告。
+func runAndCheckHealth(t *testing.T) dio.HealthReport {
+	t.Helper()
+	var report dio.HealthReport
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			report = dio.CheckHealth(context.Background())
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	return report
+}
+
+// TestCheckHealthReport 验证结构化报告：逐检查器状态/错误/详情，整体状态取最严重者。
+func TestCheckHealthReport(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.Provide(checkOK{}, checkFail{}, checkDegraded{})
+	report := runAndCheckHealth(t)
+	if report.Status != dio.StatusDown {
+		t.Fatalf("overall status = %s, want DOWN", report.Status)
+	}
+	if report.State != dio.Running {
+		t.Fatalf("report state = %s, want Running", report.State)
+	}
+	statuses := map[string]dio.ComponentHealth{}
+	for _, c := range report.Components {
+		statuses[c.Name] = c
+	}
+	if c := statuses["checkOK"]; c.Status != dio.StatusUp || c.Error != nil {
+		t.Fatalf("checkOK = %+v, want UP", c)
+	}
+	if c := statuses["checkFail"]; c.Status != dio.StatusDown || !errors.Is(c.Error, errDbDown) {
+		t.Fatalf("checkFail = %+v, want DOWN with errDbDown", c)
+	}
+	if c := statuses["checkDegraded"]; c.Status != dio.StatusDegraded || c.Details["replicas"] != 1 {
+		t.Fatalf("checkDegraded = %+v, want DEGRADED with details", c)
+	}
+	if err := report.Err(); !errors.Is(err, errDbDown) {
+		t.Fatalf("report.Err() should contain errDbDown, got %v", err)
+	}
+}
+
+// TestHealthDegraded 验证降级不视为失败：整体 DEGRADED，Health 返回 nil。
+func TestHealthDegraded(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.Provide(checkOK{}, checkDegraded{})
+	report := runAndCheckHealth(t)
+	if report.Status != dio.StatusDegraded {
+		t.Fatalf("overall status = %s, want DEGRADED", report.Status)
+	}
+	if err := report.Err(); err != nil {
+		t.Fatalf("degraded report should not be an error, got %v", err)
+	}
+}
+
+// checkSleep 固定耗时的检查器（忽略 ctx），用于验证并发与超时
+type checkSleep struct{ d time.Duration }
+
+func (c checkSleep) Health(ctx context.Context) error {
+	time.Sleep(c.d)
+	return nil
+}
+
+// checkStuck 声明 50ms 超时但执行 1s（忽略 ctx）
+type checkStuck struct{}
+
+func (checkStuck) Health(ctx context.Context) error {
+	time.Sleep(time.Second)
+	return nil
+}
+
+func (checkStuck) HealthTimeout() time.Duration { return 50 * time.Millisecond }
+
+// TestHealthConcurrent 验证检查并发执行：3 个 100ms 的检查总耗时远小于串行耗时，结果按注册顺序排列。
+func TestHealthConcurrent(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.RegisterNamedBean("sleep1", &checkSleep{d: 100 * time.Millisecond})
+	dio.RegisterNamedBean("sleep2", &checkSleep{d: 100 * time.Millisecond})
+	dio.RegisterNamedBean("sleep3", &checkSleep{d: 100 * time.Millisecond})
+	report := runAndCheckHealth(t)
+	if report.Duration >= 250*time.Millisecond {
+		t.Fatalf("concurrent checks took %v, want < 250ms", report.Duration)
+	}
+	for i, name := range []string{"sleep1", "sleep2", "sleep3"} {
+		if report.Components[i].Name != name || report.Components[i].Status != dio.StatusUp {
+			t.Fatalf("component %d = %+v, want %s UP", i, report.Components[i], name)
+		}
+	}
+}
+
+// TestHealthConcurrencyLimit 验证 health.concurrency=1 时退化为串行执行。
+func TestHealthConcurrencyLimit(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("health.concurrency", 1)
+	dio.RegisterNamedBean("sleep1", &checkSleep{d: 50 * time.Millisecond})
+	dio.RegisterNamedBean("sleep2", &checkSleep{d: 50 * time.Millisecond})
+	report := runAndCheckHealth(t)
+	if report.Duration < 100*time.Millisecond {
+		t.Fatalf("serial checks took %v, want >= 100ms", report.Duration)
+	}
+}
+
+// TestHealthCheckerTimeout 验证检查器自身声明的超时：超时记为 DOWN 且不等待检查器结束。
+func TestHealthCheckerTimeout(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.Provide(checkOK{}, checkStuck{})
+	report := runAndCheckHealth(t)
+	if report.Duration >= 500*time.Millisecond {
+		t.Fatalf("CheckHealth should not wait for stuck checker, took %v", report.Duration)
+	}
+	if report.Status != dio.StatusDown {
+		t.Fatalf("overall status = %s, want DOWN", report.Status)
+	}
+	if err := report.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("stuck checker should fail with DeadlineExceeded, got %v", err)
+	}
+}
+
+// checkBlocking 忽略 ctx、阻塞到 release 关闭的检查器，记录同时执行的数量
+type checkBlocking struct {
+	release chan struct{}
+	active  *atomic.Int32
+	peak    *atomic.Int32
+	started *atomic.Int32
+}
+
+func (c *checkBlocking) Health(ctx context.Context) error {
+	c.started.Add(1)
+	n := c.active.Add(1)
+	for {
+		peak := c.peak.Load()
+		if n <= peak || c.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	<-c.release
+	c.active.Add(-1)
+	return nil
+}
+
+// TestHealthHungCheckerConcurrency 验证超时后仍在执行的检查器占用并发槽位且不会被重复启动：
+// 多次 CheckHealth 同时执行的检查器不超过 health.concurrency。
+func TestHealthHungCheckerConcurrency(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("health.concurrency", 2).SetProperty("health.timeout", "20ms")
+	release := make(chan struct{})
+	var active, peak, started atomic.Int32
+	for _, name := range []string{"block1", "block2", "block3"} {
+		dio.RegisterNamedBean(name, &checkBlocking{release: release, active: &active, peak: &peak, started: &started})
+	}
+	var reports []dio.HealthReport
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			for i := 0; i < 5; i++ {
+				reports = append(reports, dio.CheckHealth(context.Background()))
+			}
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	close(release)
+
+	if len(reports) != 5 {
+		t.Fatalf("CheckHealth should not block on hung checkers, got %d reports", len(reports))
+	}
+	for _, report := range reports {
+		if report.Status != dio.StatusDown {
+			t.Fatalf("hung checkers should be DOWN, got %+v", report)
+		}
+	}
+	if peak.Load() > 2 || started.Load() > 2 {
+		t.Fatalf("peak concurrency = %d, started = %d, want at most 2", peak.Load(), started.Load())
+	}
+}
+
+// checkReadinessOnly 只加入 readiness 分组的检查器（失败）
+type checkReadinessOnly struct{}
+
+func (checkReadinessOnly) Health(ctx context.Context) error { return errDbDown }
+
+func (checkReadinessOnly) HealthGroups() []string { return []string{dio.HealthGroupReadiness} }
+
+// TestHealthGroups 验证健康分组：liveness 默认只看应用状态，readiness 只执行成员，配置按 bean 名称加入分组。
+func TestHealthGroups(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("health.groups.cache", "checkOK")
+	dio.Provide(checkOK{}, checkReadinessOnly{})
+	if r := dio.HealthGroup(context.Background(), dio.HealthGroupLiveness); r.Status != dio.StatusDown {
+		t.Fatalf("liveness before Run = %s, want DOWN", r.Status)
+	}
+	reports := map[string]dio.HealthReport{}
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			for _, group := range []string{dio.HealthGroupLiveness, dio.HealthGroupReadiness, dio.HealthGroupStartup, "cache", "undefined"} {
+				reports[group] = dio.HealthGroup(context.Background(), group)
+			}
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+
+	// 依赖失败不影响存活与启动探针
+	if r := reports[dio.HealthGroupLiveness]; r.Status != dio.StatusUp || len(r.Components) != 0 {
+		t.Fatalf("liveness = %+v, want UP without dependency checks", r)
+	}
+	if r := reports[dio.HealthGroupStartup]; r.Status != dio.StatusUp {
+		t.Fatalf("startup = %+v, want UP", r)
+	}
+	r := reports[dio.HealthGroupReadiness]
+	if r.Status != dio.StatusDown || len(r.Components) != 1 || r.Components[0].Name != "checkReadinessOnly" {
+		t.Fatalf("readiness = %+v, want DOWN with only checkReadinessOnly", r)
+	}
+	if !errors.Is(r.Err(), errDbDown) {
+		t.Fatalf("readiness error should contain errDbDown, got %v", r.Err())
+	}
+	r = reports["cache"]
+	if r.Status != dio.StatusUp || len(r.Components) != 1 || r.Components[0].Name != "checkOK" {
+		t.Fatalf("cache group = %+v, want UP with only checkOK", r)
+	}
+	if r := reports["undefined"]; r.Status != dio.StatusUnknown || r.Err() != nil {
+		t.Fatalf("undefined group = %+v, want UNKNOWN without error", r)
+	}
+}
+
+// checkCounting 统计被调用次数的检查器
+type checkCounting struct {
+	calls atomic.Int32
+	last  atomic.Int64 // 最近一次检查的时间（UnixNano）
+}
+
+func (c *checkCounting) Health(ctx context.Context) error {
+	c.calls.Add(1)
+	c.last.Store(time.Now().UnixNano())
+	return nil
+}
+
+// TestHealthBackground 验证后台检查：探针读取缓存结果（Cached=true），不直接触发检查。
+func TestHealthBackground(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("health.background.interval", "50ms")
+	checker := &checkCounting{}
+	dio.RegisterNamedBean("counting", checker)
+	type result struct {
+		report    dio.HealthReport
+		calls     int32
+		age       time.Duration // 报告的时效
+		sincePoll time.Duration // 距最近一次后台检查的时间
+	}
+	results := make(chan result, 1)
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			go func() {
+				time.Sleep(80 * time.Millisecond)
+				before := checker.calls.Load()
+				var report dio.HealthReport
+				for i := 0; i < 10; i++ {
+					report = dio.CheckHealth(context.Background())
+				}
+				sincePoll := time.Since(time.Unix(0, checker.last.Load()))
+				age := report.Age()
+				results <- result{report: report, calls: checker.calls.Load() - before, age: age, sincePoll: sincePoll}
+			}()
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	var r result
+	select {
+	case r = <-results:
+	case <-time.After(time.Second):
+		t.Fatal("probes did not complete")
+	}
+	if !r.report.Cached || r.report.Status != dio.StatusUp {
+		t.Fatalf("report = %+v, want cached UP", r.report)
+	}
+	if r.calls > 1 {
+		t.Fatalf("probes should read cache, checker called %d times during 10 probes", r.calls)
+	}
+	// 缓存报告的时效不小于距最近一次后台检查的时间（Time 为缓存结果的检查时间，而非读取时间）
+	if r.age < r.sincePoll || r.age > 200*time.Millisecond {
+		t.Fatalf("cached report age = %v, want >= %v since last poll and < 200ms", r.age, r.sincePoll)
+	}
+	if !r.report.Time.Equal(r.report.Components[0].Time) {
+		t.Fatalf("cached report time = %v, want component time %v", r.report.Time, r.report.Components[0].Time)
+	}
+}
+
+// checkDestroyed 记录销毁后是否仍被调用的检查器
+type checkDestroyed struct {
+	destroyed     atomic.Bool
+	afterDestroy  atomic.Int32
+	beforeDestroy atomic.Int32
+}
+
+func (c *checkDestroyed) Health(ctx context.Context) error {
+	if c.destroyed.Load() {
+		c.afterDestroy.Add(1)
+	} else {
+		c.beforeDestroy.Add(1)
+	}
+	time.Sleep(2 * time.Millisecond)
+	return nil
+}
+
+func (c *checkDestroyed) Destroy() {
+	c.destroyed.Store(true)
+}
+
+// TestHealthBackgroundStopsBeforeDestroy 验证停机时后台检查先于 bean 销毁停止，不会调用已销毁的检查器。
+func TestHealthBackgroundStopsBeforeDestroy(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("health.background.interval", "1ms")
+	checker := &checkDestroyed{}
+	dio.RegisterNamedBean("destroyed", checker)
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	if !checker.destroyed.Load() || checker.beforeDestroy.Load() == 0 {
+		t.Fatalf("checker should be polled and then destroyed, polls = %d", checker.beforeDestroy.Load())
+	}
+	if n := checker.afterDestroy.Load(); n > 0 {
+		t.Fatalf("background health polled a destroyed bean %d times", n)
+	}
+}
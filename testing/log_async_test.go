@@ -0,0 +1,95 @@
+package testing
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+func newAsyncLogger(t *testing.T, bufferSize int, overflow string) (log *dio.ZapLogger, read func() string) {
+	t.Helper()
+	read = captureStdout(t, func() {
+		l, err := dio.NewZapLoggerWithConfig(core.Property{Std: true}, dio.ZapConfig{
+			Format:             dio.LogFormatLogfmt,
+			TimeKey:            "-",
+			Async:              true,
+			AsyncBufferSize:    bufferSize,
+			AsyncFlushInterval: "1h",
+			AsyncOverflow:      overflow,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		log = l.(*dio.ZapLogger)
+	})
+	return log, read
+}
+
+// TestAsyncLogDrop 验证 drop 策略：缓冲区满时丢弃新日志并计数，Close 时写出缓冲区，之后同步写入。
+// 后台可能在缓冲区过半时提前写出，因此只校验写出与丢弃的条数之和。
+func TestAsyncLogDrop(t *testing.T) {
+	log, read := newAsyncLogger(t, 4, dio.LogOverflowDrop)
+	for i := 0; i < 10; i++ {
+		log.Info(context.Background(), "message", "i", i)
+	}
+	_ = log.Close()
+	log.Info(context.Background(), "after close")
+	stats, ok := log.AsyncStats()
+	if !ok || stats.Buffered != 0 || stats.DroppedByLevel["info"] != stats.Dropped {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	lines := strings.Split(strings.TrimSpace(read()), "\n")
+	if uint64(len(lines)-1)+stats.Dropped != 10 || !strings.Contains(lines[0], "i=0") || !strings.Contains(lines[len(lines)-1], "after close") {
+		t.Fatalf("unexpected lines (dropped %d): %v", stats.Dropped, lines)
+	}
+}
+
+// TestAsyncLogDropLowestLevel 验证 drop-lowest-level 策略：缓冲区满时优先丢弃级别最低的日志，高级别日志不丢。
+func TestAsyncLogDropLowestLevel(t *testing.T) {
+	log, read := newAsyncLogger(t, 2, dio.LogOverflowDropLowestLevel)
+	log.Info(context.Background(), "info 1")
+	log.Warn(context.Background(), "warn 1")
+	log.Error(context.Background(), "error 1")
+	log.Info(context.Background(), "info 2")
+	log.Destroy()
+
+	output := read()
+	if !strings.Contains(output, "warn 1") || !strings.Contains(output, "error 1") {
+		t.Fatalf("warn/error lines should be kept: %s", output)
+	}
+	stats, _ := log.AsyncStats()
+	if written := uint64(strings.Count(output, "info ")); written+stats.DroppedByLevel["info"] != 2 || stats.Dropped != stats.DroppedByLevel["info"] {
+		t.Fatalf("unexpected stats: %+v, output: %s", stats, output)
+	}
+}
+
+// TestAsyncLogBlock 验证 block 策略：缓冲区满时等待写出，不丢日志且保持顺序。
+func TestAsyncLogBlock(t *testing.T) {
+	log, read := newAsyncLogger(t, 1, "")
+	for i := 0; i < 5; i++ {
+		log.Info(context.Background(), "message", "i", i)
+	}
+	_ = log.Close()
+	lines := strings.Split(strings.TrimSpace(read()), "\n")
+	if len(lines) != 5 || !strings.Contains(lines[4], "i=4") {
+		t.Fatalf("unexpected lines: %v", lines)
+	}
+	if stats, _ := log.AsyncStats(); stats.Dropped != 0 || stats.Overflow != dio.LogOverflowBlock {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+}
+
+// TestAsyncLogInvalidConfig 验证异步配置非法时创建失败。
+func TestAsyncLogInvalidConfig(t *testing.T) {
+	for _, c := range []dio.ZapConfig{
+		{Async: true, AsyncOverflow: "discard"},
+		{Async: true, AsyncFlushInterval: "soon"},
+	} {
+		if _, err := dio.NewZapLoggerWithConfig(core.Property{Std: true}, c); err == nil {
+			t.Errorf("config %+v should be rejected", c)
+		}
+	}
+}
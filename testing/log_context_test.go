@@ -0,0 +1,74 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+type tenantKey struct{}
+
+// TestZapLoggerTraceField 验证 trace id 以字段输出（不再拼入 logger 名称），上下文提取字段自动附加。
+func TestZapLoggerTraceField(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true, TraceName: "X-Request-Id"}, dio.ZapConfig{Format: dio.LogFormatJSON})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	log.(*dio.ZapLogger).SetContextFieldExtractors(
+		dio.ContextFieldExtractorFunc(func(ctx context.Context) []any {
+			if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+				return []any{"tenant", tenant}
+			}
+			return nil
+		}),
+		dio.ContextFieldExtractorFunc(func(ctx context.Context) []any {
+			panic("broken extractor")
+		}),
+	)
+	ctx := log.TraceWith(context.WithValue(context.Background(), tenantKey{}, "acme"), "trace-1")
+	log.Named("order").Info(ctx, "with trace", "orderId", 1)
+	log.Info(context.Background(), "without trace")
+	lines := strings.Split(strings.TrimSpace(read()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %d: %v", len(lines), lines)
+	}
+
+	var traced, plain map[string]any
+	_ = json.Unmarshal([]byte(lines[0]), &traced)
+	_ = json.Unmarshal([]byte(lines[1]), &plain)
+	if traced["trace_id"] != "trace-1" || traced["tenant"] != "acme" || traced["logger"] != "order" {
+		t.Fatalf("unexpected traced line: %v", traced)
+	}
+	if _, ok := plain["trace_id"]; ok {
+		t.Fatalf("line without trace should not carry trace field: %v", plain)
+	}
+	if _, ok := plain["logger"]; ok {
+		t.Fatalf("trace id should not be used as logger name: %v", plain)
+	}
+}
+
+// TestZapLoggerTraceKey 验证 log.trace-key 自定义 trace id 字段 key。
+func TestZapLoggerTraceKey(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true, TraceName: "X-Request-Id"}, dio.ZapConfig{Format: dio.LogFormatJSON, TraceKey: "traceId"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	log.Info(log.TraceWith(context.Background(), "trace-2"), "custom key")
+	var line map[string]any
+	_ = json.Unmarshal([]byte(strings.TrimSpace(read())), &line)
+	if line["traceId"] != "trace-2" {
+		t.Fatalf("trace field traceId = %v, want trace-2: %v", line["traceId"], line)
+	}
+}
@@ -0,0 +1,72 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+// TestZapLoggerJSONFormat 验证 json 格式：自定义字段 key、时间格式与结构化字段。
+func TestZapLoggerJSONFormat(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true}, dio.ZapConfig{
+			Format:     dio.LogFormatJSON,
+			TimeFormat: "rfc3339",
+			TimeKey:    "@timestamp",
+			MessageKey: "message",
+			NameKey:    "-",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	log.Info(context.Background(), "hello json", "orderId", 1001)
+	output := strings.TrimSpace(read())
+
+	var line map[string]any
+	if err := json.Unmarshal([]byte(output), &line); err != nil {
+		t.Fatalf("output should be a json line: %q: %v", output, err)
+	}
+	if line["message"] != "hello json" || line["level"] != "INFO" || line["orderId"] != float64(1001) {
+		t.Fatalf("unexpected json line: %v", line)
+	}
+	if _, err := time.Parse(time.RFC3339, line["@timestamp"].(string)); err != nil {
+		t.Fatalf("@timestamp should be rfc3339: %v", err)
+	}
+	if _, ok := line["logger"]; ok {
+		t.Fatalf("logger key should be omitted: %v", line)
+	}
+}
+
+// TestZapLoggerLogfmtFormat 验证 logfmt 格式：固定字段在前，值按需加引号，嵌套对象编码为 JSON。
+func TestZapLoggerLogfmtFormat(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true}, dio.ZapConfig{StdFormat: dio.LogFormatLogfmt, TimeKey: "-"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	log.Named("order").Warn(context.Background(), "pay failed", "orderId", 1001, "reason", "no balance")
+	output := strings.TrimSpace(read())
+
+	want := `level=warn logger=order msg="pay failed" orderId=1001 reason="no balance"`
+	if output != want {
+		t.Fatalf("logfmt output = %q, want %q", output, want)
+	}
+}
+
+// TestZapLoggerInvalidFormat 验证非法格式返回错误。
+func TestZapLoggerInvalidFormat(t *testing.T) {
+	if _, err := dio.NewZapLoggerWithConfig(core.Property{Std: true}, dio.ZapConfig{Format: "xml"}); err == nil {
+		t.Fatal("NewZapLoggerWithConfig should reject unknown format")
+	}
+}
@@ -0,0 +1,160 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+	"go.uber.org/zap"
+)
+
+// captureStdout 创建日志组件时将 os.Stdout 替换为管道（zap 控制台输出在创建时绑定 os.Stdout），返回读取全部输出的函数。
+func captureStdout(t *testing.T, create func()) (read func() string) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	create()
+	os.Stdout = stdout
+	return func() string {
+		_ = w.Close()
+		data, _ := io.ReadAll(r)
+		return string(data)
+	}
+}
+
+// TestZapLoggerSetLevel 验证全局与 Named 前缀级别：前缀最长匹配，移除前缀后回退全局级别。
+func TestZapLoggerSetLevel(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		if log, err = dio.NewZapLogger(core.Property{Std: true}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	controller := log.(dio.LogLevelController)
+	if err := controller.SetLevel("", "warn"); err != nil {
+		t.Fatal(err)
+	}
+	if err := controller.SetLevel("db", "debug"); err != nil {
+		t.Fatal(err)
+	}
+	if err := controller.SetLevel("db.slow", "error"); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	log.Info(ctx, "root-info")
+	log.Warn(ctx, "root-warn")
+	log.Named("db").Debug(ctx, "db-debug")
+	log.Named("db").Named("slow").Warn(ctx, "slow-warn")
+	log.Named("db").Named("slow").Error(ctx, "slow-error")
+	_ = controller.SetLevel("db", "")
+	log.Named("db").Info(ctx, "db-info-removed")
+	output := read()
+
+	for _, want := range []string{"root-warn", "db-debug", "slow-error"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output should contain %q:\n%s", want, output)
+		}
+	}
+	for _, unwanted := range []string{"root-info", "slow-warn", "db-info-removed"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("output should not contain %q:\n%s", unwanted, output)
+		}
+	}
+	levels := controller.Levels()
+	if levels[dio.RootLoggerName] != "warn" || levels["db.slow"] != "error" || levels["db"] != "" {
+		t.Fatalf("unexpected levels: %v", levels)
+	}
+	if err := controller.SetLevel("", "verbose"); err == nil {
+		t.Fatal("SetLevel should reject invalid level")
+	}
+	if err := controller.SetLevel("", ""); err == nil {
+		t.Fatal("root level should not be removable")
+	}
+}
+
+// TestToggleDebugWithLevel 验证 ToggleDebug 与 SetLevel 共享全局级别。
+func TestToggleDebugWithLevel(t *testing.T) {
+	log, err := dio.NewZapLogger(core.Property{Std: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	controller := log.(dio.LogLevelController)
+	_ = controller.SetLevel("", "error")
+	if debug := log.(*dio.ZapLogger).ToggleDebug(); !debug {
+		t.Fatal("ToggleDebug from error should switch to debug")
+	}
+	if level := controller.Levels()[dio.RootLoggerName]; level != "debug" {
+		t.Fatalf("root level = %s, want debug", level)
+	}
+	if debug := log.(*dio.ZapLogger).ToggleDebug(); debug {
+		t.Fatal("ToggleDebug from debug should switch to info")
+	}
+	wrapped := dio.WrapZapLogger(zap.NewNop()).(dio.LogLevelController)
+	if err := wrapped.SetLevel("", "debug"); !errors.Is(err, dio.ErrLogLevelUnsupported) {
+		t.Fatalf("wrapped logger SetLevel = %v, want ErrLogLevelUnsupported", err)
+	}
+}
+
+// TestLogLevelProperties 验证 log.level / log.levels 配置在 Run 时生效，并可通过 SetLogLevel 与 /loggers 端点调整。
+func TestLogLevelProperties(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("log.file", false)
+	dio.SetProperty("log.level", "warn")
+	dio.SetProperty("log.levels.svc", "debug")
+	if err := dio.SetLogLevel("", "info"); !errors.Is(err, dio.ErrNotRun) {
+		t.Fatalf("SetLogLevel before Run = %v, want ErrNotRun", err)
+	}
+
+	var levels map[string]string
+	var code int
+	var body string
+	dio.OnStateChange(func(s dio.AppState) {
+		if s != dio.Running {
+			return
+		}
+		levels = dio.LogLevels()
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPut, "/loggers/svc", strings.NewReader(`{"level":"error"}`))
+		dio.ManagementHandler().ServeHTTP(rec, req)
+		code, body = rec.Code, rec.Body.String()
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	if levels[dio.RootLoggerName] != "warn" || levels["svc"] != "debug" {
+		t.Fatalf("levels after Run = %v, want root=warn svc=debug", levels)
+	}
+	if code != http.StatusOK || !strings.Contains(body, `"svc":"error"`) {
+		t.Fatalf("PUT /loggers/svc = %d %s", code, body)
+	}
+}
+
+// TestLogLevelInvalidProperty 验证非法的 log.level 使 Run 启动失败。
+func TestLogLevelInvalidProperty(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("log.file", false)
+	dio.SetProperty("log.level", "verbose")
+	runWithTimeout(t, func() {
+		dio.Run(context.Background())
+	})
+	if dio.State() != dio.Failed {
+		t.Fatalf("state = %s, want Failed", dio.State())
+	}
+}
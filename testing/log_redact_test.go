@@ -0,0 +1,74 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+type loginRequest struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+	Profile  struct {
+		APIToken string `json:"apiToken"`
+	} `json:"profile"`
+}
+
+// TestZapLoggerRedaction 验证按 key 与正则脱敏：keyAndValues 与 map 形式一致，嵌套结构体生效，caller 不受影响。
+func TestZapLoggerRedaction(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true, DebugMode: true}, dio.ZapConfig{Format: dio.LogFormatJSON})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	if err := log.(*dio.ZapLogger).SetRedaction(dio.LogRedaction{
+		Keys:     []string{"password", "*token*"},
+		Patterns: []string{"card-number", "bearer-token"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	req := loginRequest{User: "alice", Password: "p@ss"}
+	req.Profile.APIToken = "tk-1"
+	log.Named("auth").Info(context.Background(), "pay with 4111 1111 1111 1111", "request", req, "Authorization", "Bearer abc.def")
+	log.Infow(context.Background(), "login", map[string]any{"password": "p@ss", "user": "alice"})
+
+	lines := strings.Split(strings.TrimSpace(read()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %v", lines)
+	}
+	var first, second map[string]any
+	_ = json.Unmarshal([]byte(lines[0]), &first)
+	_ = json.Unmarshal([]byte(lines[1]), &second)
+	if first["msg"] != "pay with ******" || first["Authorization"] != "Bearer ******" {
+		t.Fatalf("message/field patterns should be redacted: %v", first)
+	}
+	request, _ := first["request"].(map[string]any)
+	profile, _ := request["profile"].(map[string]any)
+	if request["user"] != "alice" || request["password"] != "******" || profile["apiToken"] != "******" {
+		t.Fatalf("nested struct keys should be redacted: %v", request)
+	}
+	if second["password"] != "******" || second["user"] != "alice" {
+		t.Fatalf("map fields should be redacted: %v", second)
+	}
+	if caller, _ := first["caller"].(string); !strings.HasPrefix(caller, "testing/log_redact_test.go") {
+		t.Fatalf("caller should point to the test, got %v", first["caller"])
+	}
+}
+
+// TestZapLoggerRedactionInvalid 验证脱敏正则非法时返回错误。
+func TestZapLoggerRedactionInvalid(t *testing.T) {
+	log, err := dio.NewZapLogger(core.Property{Std: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := log.(*dio.ZapLogger).SetRedaction(dio.LogRedaction{Patterns: []string{"("}}); err == nil {
+		t.Fatal("invalid pattern should be rejected")
+	}
+}
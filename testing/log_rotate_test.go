@@ -0,0 +1,73 @@
+package testing
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+// TestZapLoggerRotateBySize 验证按大小滚动：历史文件 gzip 压缩，并按 max-backups 保留最新的文件。
+func TestZapLoggerRotateBySize(t *testing.T) {
+	dir := t.TempDir()
+	log, err := dio.NewZapLoggerWithConfig(core.Property{File: true, Dir: dir, Name: "app"}, dio.ZapConfig{
+		MaxSize:    "1KB",
+		MaxBackups: 2,
+		Compress:   true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 100; i++ {
+		log.Info(context.Background(), "rotate by size", "payload", strings.Repeat("x", 64))
+	}
+	if err := log.(dio.Disposable).Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var active, compressed []string
+	for _, entry := range entries {
+		info, _ := entry.Info()
+		switch name := entry.Name(); {
+		case strings.HasSuffix(name, ".log.gz"):
+			compressed = append(compressed, name)
+		case strings.HasSuffix(name, ".log"):
+			active = append(active, name)
+			if info.Size() > 1024 {
+				t.Errorf("%s exceeds max-size: %d", name, info.Size())
+			}
+		}
+	}
+	if len(active) != 1 || !strings.HasPrefix(active[0], "app_") {
+		t.Fatalf("want one active file, got %v", active)
+	}
+	if len(compressed) != 2 {
+		t.Fatalf("want 2 compressed backups, got %v", compressed)
+	}
+	for _, name := range compressed {
+		if strings.HasPrefix(name, "app_error_") {
+			t.Fatalf("error file should not be created without errors: %v", compressed)
+		}
+	}
+}
+
+// TestZapLoggerRotateConfigInvalid 验证滚动配置非法时创建失败。
+func TestZapLoggerRotateConfigInvalid(t *testing.T) {
+	for _, c := range []dio.ZapConfig{
+		{MaxSize: "ten"},
+		{RotationTime: "daily"},
+		{RotationTime: "-1h"},
+		{Pattern: "%Y-%q"},
+	} {
+		if _, err := dio.NewZapLoggerWithConfig(core.Property{File: true, Dir: t.TempDir()}, c); err == nil {
+			t.Errorf("config %+v should be rejected", c)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+// TestZapLoggerSampling 验证采样：先输出 Initial 条，之后每 Thereafter 条输出 1 条，排除的 Named logger 不采样，
+// Close 时输出被抑制条数的汇总。
+func TestZapLoggerSampling(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true}, dio.ZapConfig{Format: dio.LogFormatJSON})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	log.(*dio.ZapLogger).SetSampling(&dio.LogSampling{Initial: 2, Thereafter: 3, Tick: time.Hour, Exclude: []string{"db"}})
+	for i := 0; i < 10; i++ {
+		log.Error(context.Background(), "storm", "i", i)
+		log.Named("db").Named("pool").Info(context.Background(), "excluded")
+	}
+	_ = log.(dio.Disposable).Close()
+
+	var storm, excluded int
+	var summary map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(read()), "\n") {
+		var entry map[string]any
+		_ = json.Unmarshal([]byte(line), &entry)
+		switch entry["msg"] {
+		case "storm":
+			storm++
+		case "excluded":
+			excluded++
+		default:
+			summary = entry
+		}
+	}
+	if storm != 4 || excluded != 10 {
+		t.Fatalf("storm = %d (want 4), excluded = %d (want 10)", storm, excluded)
+	}
+	if summary == nil || summary["sampled_msg"] != "storm" || summary["suppressed"] != float64(6) || summary["level"] != "ERROR" {
+		t.Fatalf("unexpected summary line: %v", summary)
+	}
+}
+
+// TestZapLoggerSamplingTickFlush 验证周期结束后即使没有新日志，汇总也会由后台按周期输出，无需等到 Close。
+func TestZapLoggerSamplingTickFlush(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true}, dio.ZapConfig{Format: dio.LogFormatJSON})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	defer func() { _ = log.(dio.Disposable).Close() }()
+	log.(*dio.ZapLogger).SetSampling(&dio.LogSampling{Initial: 1, Tick: 50 * time.Millisecond})
+	for i := 0; i < 5; i++ {
+		log.Warn(context.Background(), "storm")
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	var summary map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(read()), "\n") {
+		var entry map[string]any
+		_ = json.Unmarshal([]byte(line), &entry)
+		if entry["msg"] == "log sampling suppressed messages" {
+			summary = entry
+		}
+	}
+	if summary == nil || summary["sampled_msg"] != "storm" || summary["suppressed"] != float64(4) {
+		t.Fatalf("summary should be flushed after the tick without further logs, got %v", summary)
+	}
+}
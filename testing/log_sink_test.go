@@ -0,0 +1,82 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+// TestZapLoggerOutputs 验证各输出独立的级别与格式，Close 关闭文件输出后再次写入会重新打开。
+func TestZapLoggerOutputs(t *testing.T) {
+	dir := t.TempDir()
+	var warn bytes.Buffer
+	log, err := dio.NewZapLoggerWithOutputs(core.Property{}, dio.ZapConfig{TimeKey: "-"}, []dio.LogOutput{
+		{Writer: &warn, Level: "warn", Format: dio.LogFormatJSON},
+		{Type: dio.LogSinkFile, Format: dio.LogFormatLogfmt, Options: map[string]any{"path": filepath.Join(dir, "app.log")}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	log.Info(context.Background(), "info line")
+	log.Warn(context.Background(), "warn line")
+	_ = log.(dio.Disposable).Close()
+	log.Info(context.Background(), "after close")
+
+	if lines := strings.Split(strings.TrimSpace(warn.String()), "\n"); len(lines) != 1 || !strings.Contains(lines[0], `"msg":"warn line"`) {
+		t.Fatalf("warn output should only contain the json warn line: %v", lines)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := string(data)
+	if !strings.Contains(file, `msg="info line"`) || !strings.Contains(file, `msg="warn line"`) || !strings.Contains(file, `msg="after close"`) {
+		t.Fatalf("unexpected file output: %s", file)
+	}
+}
+
+// TestZapLoggerSyslogOutput 验证 syslog 输出：按级别映射 severity（facility*8+severity）。
+func TestZapLoggerSyslogOutput(t *testing.T) {
+	address := filepath.Join(t.TempDir(), "log.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: address, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram unsupported: %v", err)
+	}
+	defer conn.Close()
+	log, err := dio.NewZapLoggerWithOutputs(core.Property{}, dio.ZapConfig{TimeKey: "-", LevelKey: "-"}, []dio.LogOutput{
+		{Type: dio.LogSinkSyslog, Options: map[string]any{"address": address, "tag": "dio-test", "facility": "local0"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer log.(dio.Disposable).Close()
+	log.Error(context.Background(), "syslog error")
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// local0(16)*8 + err(3) = 131
+	if line := string(buf[:n]); !strings.HasPrefix(line, "<131>") || !strings.Contains(line, "dio-test[") || !strings.Contains(line, "syslog error") {
+		t.Fatalf("unexpected syslog line: %q", line)
+	}
+}
+
+// TestZapLoggerUnknownOutput 验证未知输出类型创建失败。
+func TestZapLoggerUnknownOutput(t *testing.T) {
+	_, err := dio.NewZapLoggerWithOutputs(core.Property{}, dio.ZapConfig{}, []dio.LogOutput{{Type: "kafka"}})
+	if !errors.Is(err, dio.ErrLogSinkUnknown) {
+		t.Fatalf("err = %v, want ErrLogSinkUnknown", err)
+	}
+}
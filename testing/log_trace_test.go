@@ -0,0 +1,87 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+// TestParseTraceParent 验证 W3C traceparent 解析：上游 span 作为 ParentID，并生成新的 SpanID。
+func TestParseTraceParent(t *testing.T) {
+	trace, err := dio.ParseTraceParent(testTraceParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if trace.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || trace.ParentID != "00f067aa0ba902b7" || !trace.Sampled {
+		t.Fatalf("unexpected trace context: %+v", trace)
+	}
+	if len(trace.SpanID) != 16 || trace.SpanID == trace.ParentID {
+		t.Fatalf("SpanID should be a new 16-hex id, got %q", trace.SpanID)
+	}
+	if parent := trace.TraceParent(); !strings.HasPrefix(parent, "00-4bf92f3577b34da6a3ce929d0e0e4736-"+trace.SpanID) {
+		t.Fatalf("TraceParent = %q", parent)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01", // 全 0 trace-id
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", // 全 0 parent-id
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", // 非法版本
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", // 大写
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-x",
+	} {
+		if _, err := dio.ParseTraceParent(invalid); !errors.Is(err, dio.ErrInvalidTraceParent) {
+			t.Errorf("ParseTraceParent(%q) error = %v, want ErrInvalidTraceParent", invalid, err)
+		}
+	}
+
+	generated := dio.NewTraceContext()
+	if _, err := dio.ParseTraceParent(generated.TraceParent()); err != nil {
+		t.Fatalf("generated traceparent %q should be valid: %v", generated.TraceParent(), err)
+	}
+}
+
+// TestZapLoggerTraceParent 验证 TraceWith(traceparent) 后日志输出 trace_id/span_id，外部 span 上下文优先。
+func TestZapLoggerTraceParent(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true, TraceName: "traceparent"}, dio.ZapConfig{Format: dio.LogFormatJSON})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	zapLogger := log.(*dio.ZapLogger)
+	ctx := log.TraceWith(context.Background(), testTraceParent)
+	log.Info(ctx, "from upstream")
+	if parent := zapLogger.TraceParent(ctx); !strings.HasPrefix(parent, "00-4bf92f3577b34da6a3ce929d0e0e4736-") {
+		t.Fatalf("TraceParent should keep upstream trace id, got %q", parent)
+	}
+
+	dio.SetSpanContextReader(func(ctx context.Context) (dio.TraceContext, bool) {
+		return dio.TraceContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"}, true
+	})
+	defer dio.SetSpanContextReader(nil)
+	log.Info(ctx, "from otel")
+
+	lines := strings.Split(strings.TrimSpace(read()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %v", lines)
+	}
+	var upstream, otel map[string]any
+	_ = json.Unmarshal([]byte(lines[0]), &upstream)
+	_ = json.Unmarshal([]byte(lines[1]), &otel)
+	if upstream["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" || len(upstream["span_id"].(string)) != 16 {
+		t.Fatalf("unexpected upstream line: %v", upstream)
+	}
+	if otel["trace_id"] != "0af7651916cd43dd8448eb211c80319c" || otel["span_id"] != "b7ad6b7169203331" {
+		t.Fatalf("span context reader should take precedence: %v", otel)
+	}
+}
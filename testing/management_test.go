@@ -0,0 +1,186 @@
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+)
+
+// serveManagementRequest 通过 ManagementHandler 执行请求，返回状态码与 JSON 解码结果。
+func serveManagementRequest(t *testing.T, path string) (int, map[string]any) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	dio.ManagementHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	body := map[string]any{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("%s: invalid json %q: %v", path, rec.Body.String(), err)
+	}
+	return rec.Code, body
+}
+
+// TestManagementHandler 验证管理端点的路由、状态码与敏感配置掩码。
+func TestManagementHandler(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("app.name", "demo")
+	dio.SetProperty("db.password", "123456")
+	dio.SetProperty("db.url", "mysql://root:123456@db:3306/app")
+	dio.SetProperty("db.replicas", []any{"root:123456@tcp(replica:3306)/app", map[string]any{"token": "abc"}})
+	dio.Provide(checkOK{}, checkFail{})
+
+	type response struct {
+		code int
+		body map[string]any
+	}
+	responses := map[string]response{}
+	dio.OnStateChange(func(s dio.AppState) {
+		if s != dio.Running {
+			return
+		}
+		for _, path := range []string{"/health", "/health/liveness", "/ready", "/live", "/info", "/beans", "/env"} {
+			code, body := serveManagementRequest(t, path)
+			responses[path] = response{code: code, body: body}
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+
+	if r := responses["/health"]; r.code != http.StatusServiceUnavailable || r.body["status"] != "DOWN" {
+		t.Fatalf("/health = %d %v, want 503 DOWN", r.code, r.body["status"])
+	}
+	if r := responses["/ready"]; r.code != http.StatusServiceUnavailable {
+		t.Fatalf("/ready = %d, want 503", r.code)
+	}
+	if r := responses["/info"]; r.code != http.StatusOK || r.body["state"] != "Running" {
+		t.Fatalf("/info = %d %v, want 200 Running", r.code, r.body)
+	}
+	if beans, _ := responses["/beans"].body["beans"].([]any); len(beans) == 0 {
+		t.Fatalf("/beans should list beans, got %v", responses["/beans"].body)
+	}
+	props, _ := responses["/env"].body["properties"].(map[string]any)
+	db, _ := props["db"].(map[string]any)
+	if db["password"] != "******" {
+		t.Fatalf("/env should mask db.password, got %v", props["db"])
+	}
+	if db["url"] != "mysql://root:******@db:3306/app" {
+		t.Fatalf("/env should mask the password in db.url, got %v", db["url"])
+	}
+	if replicas, _ := db["replicas"].([]any); len(replicas) != 2 || replicas[0] != "root:******@tcp(replica:3306)/app" ||
+		replicas[1].(map[string]any)["token"] != "******" {
+		t.Fatalf("/env should mask list elements, got %v", db["replicas"])
+	}
+	app, _ := props["app"].(map[string]any)
+	if app["name"] != "demo" {
+		t.Fatalf("/env app.name = %v, want demo", app["name"])
+	}
+}
+
+// TestManagementServer 验证 management.enabled 开启后在 management.port 上提供服务，停机后关闭。
+func TestManagementServer(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+	dio.SetProperty("management.enabled", true)
+	dio.SetProperty("management.address", "127.0.0.1")
+	dio.SetProperty("management.port", port)
+	dio.Provide(checkOK{})
+
+	url := fmt.Sprintf("http://127.0.0.1:%d/live", port)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		dio.Run(ctx)
+	}()
+
+	var code int
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(20 * time.Millisecond) {
+		resp, err := http.Get(url)
+		if err != nil {
+			continue
+		}
+		code = resp.StatusCode
+		_ = resp.Body.Close()
+		break
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not return after cancel")
+	}
+	if code != http.StatusOK {
+		t.Fatalf("GET /live = %d, want 200", code)
+	}
+	if resp, err := http.Get(url); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("management server should be closed after shutdown")
+	}
+}
+
+// TestManagementStopsBeforeDestroy 验证停机时管理端点先于 bean 销毁关闭，/health 不会调用已销毁的检查器。
+func TestManagementStopsBeforeDestroy(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+	dio.SetProperty("management.enabled", true)
+	dio.SetProperty("management.port", port)
+	checker := &checkDestroyed{}
+	dio.RegisterNamedBean("destroyed", checker)
+
+	done := make(chan struct{})
+	polled := make(chan struct{})
+	dio.OnStateChange(func(s dio.AppState) {
+		if s != dio.Running {
+			return
+		}
+		go func() {
+			defer close(polled)
+			client := &http.Client{Timeout: time.Second}
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if resp, err := client.Get(fmt.Sprintf("http://127.0.0.1:%d/health", port)); err == nil {
+					_ = resp.Body.Close()
+				}
+			}
+		}()
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	close(done)
+	<-polled
+
+	if !checker.destroyed.Load() || checker.beforeDestroy.Load() == 0 {
+		t.Fatalf("/health should reach the checker before it is destroyed, calls = %d", checker.beforeDestroy.Load())
+	}
+	if n := checker.afterDestroy.Load(); n > 0 {
+		t.Fatalf("/health was served by a destroyed bean %d times", n)
+	}
+}
@@ -0,0 +1,198 @@
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+)
+
+type restartFeature struct{}
+
+// TestRestart 验证 Stopped 后 Restart 重新执行完整生命周期，条件装配按当前配置重新匹配。
+func TestRestart(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("feature.on", "false")
+	dio.ProvideOnProperty(restartFeature{}, "feature.on", "true")
+	var present []bool
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			_, ok := dio.GetByType(restartFeature{})
+			present = append(present, ok)
+		}
+	})
+	run := func(fn func(ctx context.Context)) {
+		runWithTimeout(t, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			fn(ctx)
+		})
+	}
+	run(dio.Run)
+	if dio.State() != dio.Stopped {
+		t.Fatalf("after Run, state should be Stopped, got %s", dio.State())
+	}
+
+	// Stopped 后直接 Run 被拒绝
+	var runErr error
+	runWithTimeout(t, func() {
+		defer func() {
+			if r := recover(); r != nil {
+				runErr, _ = r.(error)
+			}
+		}()
+		dio.Run(context.Background())
+	})
+	if !errors.Is(runErr, dio.ErrAlreadyRun) {
+		t.Fatalf("Run after Stopped should panic ErrAlreadyRun, got %v", runErr)
+	}
+
+	dio.SetProperty("feature.on", "true")
+	run(dio.Restart)
+	if dio.State() != dio.Stopped {
+		t.Fatalf("after Restart, state should be Stopped, got %s", dio.State())
+	}
+	if len(present) != 2 || present[0] || !present[1] {
+		t.Fatalf("conditional bean presence per run = %v, want [false true]", present)
+	}
+	if v := dio.GetPropertyString("feature.on"); v != "true" {
+		t.Fatalf("properties should survive Restart, feature.on = %q", v)
+	}
+	// 首次 Run、被拒绝的 Run（不产生状态变更）与 Restart 的完整状态序列
+	want := [][2]dio.AppState{
+		{dio.Pending, dio.Starting}, {dio.Starting, dio.Running}, {dio.Running, dio.Stopping}, {dio.Stopping, dio.Stopped},
+		{dio.Stopped, dio.Starting}, {dio.Starting, dio.Running}, {dio.Running, dio.Stopping}, {dio.Stopping, dio.Stopped},
+	}
+	history := dio.StateHistory()
+	if len(history) != len(want) {
+		t.Fatalf("state history = %v, want %d transitions", history, len(want))
+	}
+	for i, transition := range history {
+		if transition.From != want[i][0] || transition.To != want[i][1] {
+			t.Fatalf("transition %d = %s -> %s, want %s -> %s", i, transition.From, transition.To, want[i][0], want[i][1])
+		}
+	}
+}
+
+// TestRestartLogOutput 验证 Restart 后 Run 创建的日志组件重新创建，文件输出不因上次停机关闭而丢失。
+func TestRestartLogOutput(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	path := filepath.Join(t.TempDir(), "app.log")
+	dio.SetProperty("log.outputs", []any{map[string]any{"type": dio.LogSinkFile, "path": path}})
+	dio.SetProperty("log.async.enabled", "true")
+	runs := 0
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			runs++
+			dio.Logger().Info(context.Background(), fmt.Sprintf("running %d", runs))
+		}
+	})
+	run := func(fn func(ctx context.Context)) {
+		runWithTimeout(t, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			fn(ctx)
+		})
+	}
+	run(dio.Run)
+	run(dio.Restart)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := string(data); !strings.Contains(out, "running 1") || !strings.Contains(out, "running 2") {
+		t.Fatalf("log file should contain both runs: %s", out)
+	}
+}
+
+// closeRecordWriter 记录写入内容与 Close 调用，关闭后写入返回 os.ErrClosed。
+type closeRecordWriter struct {
+	mu     sync.Mutex
+	buf    strings.Builder
+	closed bool
+}
+
+func (w *closeRecordWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	return w.buf.Write(p)
+}
+
+func (w *closeRecordWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.closed = true
+	return nil
+}
+
+// TestRestartRegisteredLogWriter 验证 RegisterLogWriter 注册的 writer 不随停机关闭，Restart 后继续写入。
+func TestRestartRegisteredLogWriter(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	w := &closeRecordWriter{}
+	dio.Use(dio.RegisterLogWriter("audit", w))
+	dio.SetProperty("log.outputs", []any{map[string]any{"type": "audit"}})
+	runs := 0
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			runs++
+			dio.Logger().Info(context.Background(), fmt.Sprintf("running %d", runs))
+		}
+	})
+	run := func(fn func(ctx context.Context)) {
+		runWithTimeout(t, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			fn(ctx)
+		})
+	}
+	run(dio.Run)
+	run(dio.Restart)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		t.Fatal("registered writer should not be closed by the log component")
+	}
+	if out := w.buf.String(); !strings.Contains(out, "running 1") || !strings.Contains(out, "running 2") {
+		t.Fatalf("registered writer should receive both runs: %s", out)
+	}
+}
+
+// TestRestartWhileRunning 验证非 Stopped/Failed 状态调用 Restart 会 panic（ErrAlreadyRun）。
+func TestRestartWhileRunning(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	var restartErr error
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			defer func() {
+				if r := recover(); r != nil {
+					restartErr, _ = r.(error)
+				}
+			}()
+			dio.Restart(context.Background())
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	if !errors.Is(restartErr, dio.ErrAlreadyRun) {
+		t.Fatalf("Restart while Running should panic ErrAlreadyRun, got %v", restartErr)
+	}
+}
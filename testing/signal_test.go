@@ -0,0 +1,69 @@
+//go:build unix
+
+package testing
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+)
+
+// TestOnSignal 验证自定义信号处理器在 Running 状态下收到信号时执行，且 ctx 在停机时取消。
+func TestOnSignal(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	handled := make(chan context.Context, 1)
+	dio.OnSignal(syscall.SIGUSR1, func(ctx context.Context) {
+		handled <- ctx
+	})
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			_ = syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	select {
+	case ctx := <-handled:
+		if ctx.Err() == nil {
+			t.Fatal("signal handler ctx should be canceled after shutdown")
+		}
+	default:
+		t.Fatal("signal handler should be called while Running")
+	}
+}
+
+// TestOnSignalPanic 验证处理器 panic 被恢复，后续信号仍能分发。
+func TestOnSignalPanic(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	calls := make(chan struct{}, 2)
+	dio.OnSignal(syscall.SIGUSR2, func(ctx context.Context) {
+		calls <- struct{}{}
+		panic("boom")
+	})
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			go func() {
+				_ = syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+				time.Sleep(50 * time.Millisecond)
+				_ = syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+			}()
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	if len(calls) != 2 {
+		t.Fatalf("signal handler calls = %d, want 2", len(calls))
+	}
+}
@@ -0,0 +1,105 @@
+package testing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cheivin/dio"
+	"github.com/cheivin/dio-core"
+)
+
+// TestSlogHandler 验证基于 ZapLogger 的 slog.Handler：级别映射、分组前缀、trace 字段与 Named 前缀级别。
+func TestSlogHandler(t *testing.T) {
+	var log core.Log
+	read := captureStdout(t, func() {
+		var err error
+		log, err = dio.NewZapLoggerWithConfig(core.Property{Std: true, TraceName: "X-Request-Id"}, dio.ZapConfig{Format: dio.LogFormatJSON})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	_ = log.(dio.LogLevelController).SetLevel("quiet", "error")
+	logger := slog.New(dio.NewSlogHandler(log))
+	ctx := log.TraceWith(context.Background(), "trace-slog")
+	logger.WithGroup("req").With("method", "GET").InfoContext(ctx, "request", slog.Group("user", "id", 7))
+	logger.Debug("debug dropped")
+	slog.New(dio.NewSlogHandler(log.Named("quiet"))).Warn("warn dropped")
+	logger.Log(ctx, slog.LevelWarn+1, "warn+1")
+
+	lines := strings.Split(strings.TrimSpace(read()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("want 2 lines, got %v", lines)
+	}
+	var request, warn map[string]any
+	_ = json.Unmarshal([]byte(lines[0]), &request)
+	_ = json.Unmarshal([]byte(lines[1]), &warn)
+	if request["req.method"] != "GET" || request["req.user.id"] != float64(7) || request["trace_id"] != "trace-slog" {
+		t.Fatalf("unexpected request line: %v", request)
+	}
+	if warn["level"] != "WARN" || warn["msg"] != "warn+1" {
+		t.Fatalf("unexpected warn line: %v", warn)
+	}
+}
+
+// TestSlogLogger 验证基于 *slog.Logger 的 core.Log：Named 名称、trace 字段、map 字段与调用位置。
+func TestSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log := dio.NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{AddSource: true})))
+	ctx := log.Trace(context.Background())
+	log.Named("order").Infow(ctx, "paid", map[string]any{"orderId": 1001})
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("invalid json %q: %v", buf.String(), err)
+	}
+	if line["logger"] != "order" || line["orderId"] != float64(1001) || line["msg"] != "paid" {
+		t.Fatalf("unexpected line: %v", line)
+	}
+	if traceID, _ := line["trace_id"].(string); len(traceID) != 32 {
+		t.Fatalf("trace_id should be a W3C trace id, got %v", line["trace_id"])
+	}
+	source, _ := line["source"].(map[string]any)
+	if file, _ := source["file"].(string); !strings.HasSuffix(file, "slog_test.go") {
+		t.Fatalf("source should point to the caller, got %v", source)
+	}
+}
+
+// TestSlogDefaultRestored 验证 log.slog-default 替换的 slog 默认 logger 在停机与重启后还原，不指向已关闭的日志组件。
+func TestSlogDefaultRestored(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	previous := slog.Default()
+	dio.SetProperty("log.slog-default", true)
+	var running *slog.Logger
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			running = slog.Default()
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	if running == nil || running == previous {
+		t.Fatal("slog default logger should be replaced while Running")
+	}
+	if slog.Default() != previous {
+		t.Fatal("slog default logger should be restored after Stopped")
+	}
+
+	running = nil
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		dio.Restart(ctx)
+	})
+	if running == nil || running == previous || slog.Default() != previous {
+		t.Fatal("slog default logger should be replaced again on Restart and restored afterwards")
+	}
+}
@@ -2,10 +2,12 @@ package testing
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cheivin/dio"
+	"github.com/cheivin/dio/diotest"
 )
 
 // TestStartupDuration 验证启动耗时统计：未 Run 为 0，Run 后大于 0。
@@ -24,3 +26,84 @@ func TestStartupDuration(t *testing.T) {
 		t.Fatalf("after Run, StartupDuration should be > 0, got %v", dur)
 	}
 }
+
+type slowFactoryBean struct{}
+
+// TestStartupReport 验证启动耗时报告：阶段按执行顺序记录，工厂函数构造耗时计入对应 bean 并按耗时降序排列。
+func TestStartupReport(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	dio.SetProperty("startup.slow-threshold", "10ms")
+	dio.Provide(checkOK{})
+	dio.ProvideFunc(func() *slowFactoryBean {
+		time.Sleep(30 * time.Millisecond)
+		return &slowFactoryBean{}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+
+	report := dio.StartupReport()
+	if report.Total <= 0 {
+		t.Fatalf("startup total should be > 0, got %v", report.Total)
+	}
+	wantPhases := []string{"prepare", "logger", "register", "load", "after-run"}
+	if len(report.Phases) != len(wantPhases) {
+		t.Fatalf("phases = %v, want %v", report.Phases, wantPhases)
+	}
+	for i, name := range wantPhases {
+		if report.Phases[i].Name != name {
+			t.Fatalf("phase %d = %s, want %s", i, report.Phases[i].Name, name)
+		}
+	}
+	slowest := report.Slowest(1)
+	if len(slowest) != 1 || slowest[0].Name != "slowFactoryBean" || slowest[0].Construct < 30*time.Millisecond {
+		t.Fatalf("slowest bean should be the slow factory (>= 30ms construct), got %+v", slowest)
+	}
+	found := false
+	for _, timing := range report.Beans {
+		found = found || timing.Name == "checkOK"
+	}
+	if !found {
+		t.Fatalf("prototype bean checkOK should have a register timing: %+v", report.Beans)
+	}
+	for i := 1; i < len(report.Beans); i++ {
+		if report.Beans[i].Total() > report.Beans[i-1].Total() {
+			t.Fatalf("beans should be sorted by total cost descending: %+v", report.Beans)
+		}
+	}
+}
+
+// slowInitBean AfterPropertiesSet 耗时较长的原型 bean
+type slowInitBean struct{}
+
+func (*slowInitBean) AfterPropertiesSet() {
+	time.Sleep(50 * time.Millisecond)
+}
+
+// TestStartupReportInit 验证原型 bean 的 AfterPropertiesSet 耗时计入 Init：慢初始化的 bean 排在最前并触发慢 bean 警告。
+func TestStartupReportInit(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	capture := diotest.NewCaptureLogger()
+	dio.SetProperty("startup.slow-threshold", "20ms")
+	dio.Provide(checkOK{}, slowInitBean{})
+	dio.SetLogger(capture)
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+
+	slowest := dio.StartupReport().Slowest(1)
+	if len(slowest) != 1 || slowest[0].Name != "slowInitBean" || slowest[0].Init < 40*time.Millisecond {
+		t.Fatalf("slowest bean should be slowInitBean (>= 40ms init), got %+v", slowest)
+	}
+	if entries := capture.Filter(func(e diotest.Entry) bool {
+		return e.Level == diotest.LevelWarn && strings.HasPrefix(e.Message, "bean slowInitBean took ")
+	}); len(entries) != 1 {
+		t.Fatalf("slow init should trigger a threshold warning: %v", capture.Entries())
+	}
+}
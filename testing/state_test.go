@@ -2,7 +2,9 @@ package testing
 
 import (
 	"context"
+	"errors"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -124,3 +126,118 @@ func TestStateString(t *testing.T) {
 		t.Errorf("invalid AppState String() = %q, want prefix AppState(", s)
 	}
 }
+
+// TestStateHistory 验证状态变更历史：按时间先后记录完整推进过程，Failed 记录失败原因。
+func TestStateHistory(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	if h := dio.StateHistory(); len(h) != 0 {
+		t.Fatalf("before Run, StateHistory should be empty, got %v", h)
+	}
+	dio.RequireProperties("app.port")
+	runWithTimeout(t, func() {
+		dio.Run(context.Background())
+	})
+	dio.SetProperty("app.port", 8080)
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+
+	history := dio.StateHistory()
+	want := []struct{ from, to dio.AppState }{
+		{dio.Pending, dio.Starting},
+		{dio.Starting, dio.Failed},
+		{dio.Failed, dio.Starting},
+		{dio.Starting, dio.Running},
+		{dio.Running, dio.Stopping},
+		{dio.Stopping, dio.Stopped},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("StateHistory = %v, want %d transitions", history, len(want))
+	}
+	for i, w := range want {
+		if history[i].From != w.from || history[i].To != w.to {
+			t.Fatalf("transition %d = %s -> %s, want %s -> %s", i, history[i].From, history[i].To, w.from, w.to)
+		}
+		if i > 0 && history[i].Time.Before(history[i-1].Time) {
+			t.Fatalf("transition %d time should not be before previous one", i)
+		}
+	}
+	if !errors.Is(history[1].Err, dio.ErrMissingProperty) {
+		t.Fatalf("Failed transition should carry ErrMissingProperty, got %v", history[1].Err)
+	}
+	if history[3].Err != nil {
+		t.Fatalf("non-Failed transition should have no error, got %v", history[3].Err)
+	}
+}
+
+// TestOnStateChangeReplay 验证 Run 后注册的回调先重放已发生的状态，再接收后续推进，不重复不遗漏。
+func TestOnStateChangeReplay(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	var late []dio.AppState
+	dio.OnStateChange(func(s dio.AppState) {
+		if s == dio.Running {
+			// 模拟运行期挂载的监控插件
+			dio.OnStateChange(func(s dio.AppState) {
+				late = append(late, s)
+			})
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	want := []dio.AppState{dio.Starting, dio.Running, dio.Stopping, dio.Stopped}
+	if len(late) != len(want) {
+		t.Fatalf("late subscriber states = %v, want %v", late, want)
+	}
+	for i := range want {
+		if late[i] != want[i] {
+			t.Fatalf("late subscriber states = %v, want %v", late, want)
+		}
+	}
+}
+
+// TestOnStateChangeConcurrentOrder 验证与状态推进并发注册的回调仍按顺序收到全部状态，重放与实时通知不乱序。
+func TestOnStateChangeConcurrentOrder(t *testing.T) {
+	defer dio.Reset()
+	dio.SetBanner("")
+	const subscribers = 8
+	var wg sync.WaitGroup
+	received := make([][]dio.AppState, subscribers)
+	dio.OnStateChange(func(s dio.AppState) {
+		if s != dio.Starting {
+			return
+		}
+		for i := 0; i < subscribers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				dio.OnStateChange(func(s dio.AppState) {
+					received[i] = append(received[i], s)
+				})
+			}(i)
+		}
+	})
+	runWithTimeout(t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		dio.Run(ctx)
+	})
+	wg.Wait()
+	want := []dio.AppState{dio.Starting, dio.Running, dio.Stopping, dio.Stopped}
+	for i, states := range received {
+		if len(states) != len(want) {
+			t.Fatalf("subscriber %d states = %v, want %v", i, states, want)
+		}
+		for j := range want {
+			if states[j] != want[j] {
+				t.Fatalf("subscriber %d states = %v, want %v", i, states, want)
+			}
+		}
+	}
+}
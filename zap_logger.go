@@ -2,6 +2,7 @@ package dio
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"path"
@@ -9,7 +10,6 @@ import (
 	"time"
 
 	"github.com/cheivin/dio-core"
-	rotatelogs "github.com/lestrrat-go/file-rotatelogs"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -21,15 +21,69 @@ type Disposable interface {
 }
 
 type ZapLogger struct {
-	traceName string // 会话追踪名称
-	logger    *zap.SugaredLogger
+	traceName  string     // 会话追踪名称（trace id 在 ctx 中的 key，与请求头对齐）
+	traceKey   string     // trace id 字段 key（默认 trace_id）
+	spanKey    string     // span id 字段 key（默认 span_id）
+	name       string     // Named 名称（多级以 . 连接，用于匹配 Named 前缀级别）
+	levels     *logLevels // 输出级别（NewZapLogger 创建时可运行期调整；WrapZapLogger 包装的外部 logger 为 nil）
+	logger     *zap.SugaredLogger
+	extractors *contextExtractors // 上下文字段提取器（Named/Skip 派生的 logger 间共享）
+	async      *asyncQueue        // 异步写出队列（log.async.enabled 开启时）
+	redactor   *logRedactor       // 脱敏规则（Named/Skip 派生的 logger 间共享）
+	sampling   *logSampling       // 采样器（Named/Skip 派生的 logger 间共享）
+	sinks      []io.Closer        // 需要关闭的输出（文件/syslog/注册的输出），Close/Destroy 时关闭
 }
 
 func WrapZapLogger(logger *zap.Logger, opts ...zap.Option) core.Log {
-	return &ZapLogger{logger: logger.WithOptions(opts...).Sugar()}
+	logger = logger.WithOptions(opts...)
+	return &ZapLogger{
+		logger:     logger.Sugar(),
+		extractors: &contextExtractors{},
+		redactor:   &logRedactor{},
+		sampling:   &logSampling{logger: logger.WithOptions(zap.WithCaller(false))},
+	}
+}
+
+// ZapConfig core.Property 之外的日志扩展配置（log.* 前缀，Run 创建日志组件时读取）。
+type ZapConfig struct {
+	Format        string `value:"format"`          // 输出格式：console（默认）/json/logfmt
+	FileFormat    string `value:"file-format"`     // 文件输出格式，默认取 Format
+	ErrorFormat   string `value:"error-format"`    // 错误文件输出格式，默认取 FileFormat
+	StdFormat     string `value:"std-format"`      // 控制台输出格式，默认取 Format
+	TimeFormat    string `value:"time-format"`     // 时间格式：Go 时间布局或 iso8601/rfc3339/rfc3339nano/epoch/epoch-millis/epoch-nanos；默认 json 为 iso8601，其余为 2006-01-02 15:04:05
+	Color         string `value:"color"`           // 控制台 console 格式的级别着色：true（默认）/false
+	TraceKey      string `value:"trace-key"`       // trace id 字段 key，默认 trace_id
+	SpanKey       string `value:"span-key"`        // span id 字段 key，默认 span_id
+	TimeKey       string `value:"keys.time"`       // 时间字段 key，默认 ts（"-" 不输出，下同）
+	LevelKey      string `value:"keys.level"`      // 级别字段 key，默认 level
+	NameKey       string `value:"keys.name"`       // logger 名称字段 key，默认 logger
+	CallerKey     string `value:"keys.caller"`     // 调用者字段 key，默认 caller
+	MessageKey    string `value:"keys.message"`    // 消息字段 key，默认 msg
+	StacktraceKey string `value:"keys.stacktrace"` // 堆栈字段 key，默认 stacktrace
+	Pattern       string `value:"pattern"`         // 文件名中的时间部分（%Y %m %d %H %M %S），默认 %Y-%m-%d
+	RotationTime  string `value:"rotation-time"`   // 按时间滚动的间隔（如 1h），默认 24h
+	MaxSize       string `value:"max-size"`        // 单个文件大小上限（如 100MB、512KB，纯数字按 MB），超出后滚动；默认不限制
+	MaxBackups    int    `value:"max-backups"`     // 历史文件保留数量（与 max-age 同时生效），默认不限制
+	Compress      bool   `value:"compress"`        // 是否 gzip 压缩滚动后的历史文件
+
+	Async              bool   `value:"async.enabled"`        // 是否异步写出（调用方编码后入缓冲区，后台批量写出）
+	AsyncBufferSize    int    `value:"async.buffer-size"`    // 缓冲区容量（条），默认 8192
+	AsyncFlushInterval string `value:"async.flush-interval"` // 写出间隔，默认 1s（缓冲区过半时提前写出）
+	AsyncOverflow      string `value:"async.overflow"`       // 缓冲区满时的策略：block（默认）/drop-lowest-level/drop
 }
 
 func NewZapLogger(l core.Property, opts ...zap.Option) (core.Log, error) {
+	return NewZapLoggerWithConfig(l, ZapConfig{}, opts...)
+}
+
+// NewZapLoggerWithConfig 按基础配置 l 与扩展配置 c 创建日志组件（c 的零值等价于 NewZapLogger）。
+func NewZapLoggerWithConfig(l core.Property, c ZapConfig, opts ...zap.Option) (core.Log, error) {
+	return newZapLogger(l, c, nil, nil, opts...)
+}
+
+// newZapLogger 创建日志组件：outputs 非空时按 outputs 创建各输出（factories 为注册的输出类型），
+// 否则按 l 的 file/std 创建默认的文件、错误文件与控制台输出。
+func newZapLogger(l core.Property, c ZapConfig, outputs []LogOutput, factories map[string]LogSinkFactory, opts ...zap.Option) (core.Log, error) {
 	// 处理配置参数默认值
 	if l.Name == "" {
 		l.Name = "log"
@@ -45,60 +99,116 @@ func NewZapLogger(l core.Property, opts ...zap.Option) (core.Log, error) {
 	if l.File == false && l.Std == false {
 		l.Std = true
 	}
-	// 开始配置zap日志
-	var levelEnable zap.LevelEnablerFunc
+	// 开始配置zap日志（级别表支持运行期按全局/Named 前缀调整，见 SetLevel）
+	levels := newLogLevels(zapcore.InfoLevel)
 	var options []zap.Option
 	if l.DebugMode {
-		levelEnable = func(lvl zapcore.Level) bool {
-			return lvl >= zapcore.DebugLevel
-		}
+		levels = newLogLevels(zapcore.DebugLevel)
 		options = append(options, zap.AddCaller(), zap.AddCallerSkip(2))
-	} else {
-		levelEnable = func(lvl zapcore.Level) bool {
-			return lvl >= zapcore.InfoLevel
-		}
+	}
+	levelEnable := levels.min
+	// 各输出的编码器：文件/错误文件/控制台可分别配置格式
+	if c.FileFormat == "" {
+		c.FileFormat = c.Format
+	}
+	if c.ErrorFormat == "" {
+		c.ErrorFormat = c.FileFormat
+	}
+	if c.StdFormat == "" {
+		c.StdFormat = c.Format
+	}
+	fileEncoder, err := newLogEncoder(c.FileFormat, false, c)
+	if err != nil {
+		return nil, err
+	}
+	errorEncoder, err := newLogEncoder(c.ErrorFormat, false, c)
+	if err != nil {
+		return nil, err
+	}
+	stdEncoder, err := newLogEncoder(c.StdFormat, !strings.EqualFold(c.Color, "false"), c)
+	if err != nil {
+		return nil, err
+	}
+	rotate, err := newRotateConfig(c, time.Duration(l.MaxAge)*time.Hour*24)
+	if err != nil {
+		return nil, err
+	}
+	async, err := newAsyncQueue(c)
+	if err != nil {
+		return nil, err
+	}
+	newCore := zapcore.NewCore
+	if async != nil {
+		newCore = async.core
 	}
 	var cores []zapcore.Core
-	// 输出到文件
-	if l.File {
-		cores = []zapcore.Core{}
-
-		// 逐个创建 writer；仅创建失败时关闭已创建的，避免文件句柄泄漏。
-		// 成功路径不关闭（writer 由 ZapLogger 生命周期管理）。
-		var writers []io.Closer
-		ok := false
-		defer func() {
-			if !ok {
-				for _, w := range writers {
-					_ = w.Close()
+	// 逐个创建 writer；仅创建失败时关闭已创建的，避免文件句柄泄漏。
+	// 成功路径不关闭（writer 由 ZapLogger 生命周期管理，Close/Destroy 时关闭）。
+	var writers []io.Closer
+	ok := false
+	defer func() {
+		if !ok {
+			for _, w := range writers {
+				_ = w.Close()
+			}
+		}
+	}()
+	if len(outputs) > 0 {
+		// 按 log.outputs 创建输出，各输出有独立的级别与格式
+		for i, output := range outputs {
+			writer, err := newLogSink(output, c, rotate.maxAge, factories)
+			if err != nil {
+				return nil, fmt.Errorf("log.outputs[%d] %s: %w", i, output.Type, err)
+			}
+			if closer, ok := writer.(io.Closer); ok && writer != os.Stdout && writer != os.Stderr {
+				switch writer.(type) {
+				case *rotateWriter, *fileSink, *syslogSink:
+					writers = append(writers, closer)
+				default:
+					writers = append(writers, &onceCloser{closer: closer})
 				}
 			}
-		}()
-		if infoWriter, err := getLogWriter(path.Join(l.Dir, l.Name)+".log", time.Duration(l.MaxAge)*time.Hour*24); err != nil {
+			sinkCores, err := newSinkCores(output, writer, c, levelEnable, newCore)
+			if err != nil {
+				return nil, fmt.Errorf("log.outputs[%d] %s: %w", i, output.Type, err)
+			}
+			cores = append(cores, sinkCores...)
+		}
+	}
+	// 输出到文件
+	if len(outputs) == 0 && l.File {
+		if infoWriter, err := getLogWriter(path.Join(l.Dir, l.Name)+".log", rotate); err != nil {
 			return nil, err
 		} else {
 			writers = append(writers, infoWriter)
-			cores = append(cores, zapcore.NewCore(getLogEncoder(false), zapcore.AddSync(infoWriter), levelEnable))
+			cores = append(cores, newCore(fileEncoder, zapcore.AddSync(infoWriter), levelEnable))
 		}
-		if errorWriter, err := getLogWriter(path.Join(l.Dir, l.Name)+"_error.log", time.Duration(l.MaxAge)*time.Hour*24); err != nil {
+		if errorWriter, err := getLogWriter(path.Join(l.Dir, l.Name)+"_error.log", rotate); err != nil {
 			return nil, err
 		} else {
 			writers = append(writers, errorWriter)
-			cores = append(cores, zapcore.NewCore(getLogEncoder(false), zapcore.AddSync(errorWriter), zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
+			cores = append(cores, newCore(errorEncoder, zapcore.AddSync(errorWriter), zap.LevelEnablerFunc(func(lvl zapcore.Level) bool {
 				return lvl >= zapcore.ErrorLevel
 			})))
 		}
-		ok = true
 	}
 	// 输出到控制台,
-	if len(cores) == 0 || l.Std {
-		cores = append(cores, zapcore.NewCore(getLogEncoder(true), zapcore.Lock(os.Stdout), levelEnable))
+	if len(cores) == 0 || (len(outputs) == 0 && l.Std) {
+		cores = append(cores, newCore(stdEncoder, zapcore.Lock(os.Stdout), levelEnable))
 	}
+	ok = true
 	core := zapcore.NewTee(cores...)
 	zapLogger := zap.New(core).WithOptions(options...)
 
 	logger := WrapZapLogger(zapLogger, opts...).(*ZapLogger)
 	logger.traceName = l.TraceName
+	logger.traceKey, logger.spanKey = c.TraceKey, c.SpanKey
+	logger.levels = levels
+	logger.sinks = writers
+	if async != nil {
+		logger.async = async
+		async.start()
+	}
 	return logger, nil
 }
 
@@ -116,42 +226,16 @@ func checkDir(filename string) error {
 	return nil
 }
 
-func getLogWriter(filename string, maxAge time.Duration) (io.WriteCloser, error) {
+// getLogWriter 创建滚动日志文件：filename 为 {dir}/{name}.log 时实际写入 {dir}/{name}_{pattern}.log。
+func getLogWriter(filename string, config rotateConfig) (io.WriteCloser, error) {
 	if err := checkDir(filename); err != nil {
 		return nil, err
 	}
-	if writer, err := rotatelogs.New(
-		strings.Replace(filename, ".log", "", -1)+"_%Y-%m-%d.log",
-		rotatelogs.WithMaxAge(maxAge),
-		rotatelogs.WithRotationTime(24*time.Hour),
-	); err != nil {
+	writer, err := newRotateWriter(strings.TrimSuffix(filename, ".log")+"_", config)
+	if err != nil {
 		return nil, err
-	} else {
-		return writer, nil
 	}
-}
-
-// getLogEncoder 构建控制台编码器。color 为 true 时 level 输出带颜色（用于控制台）。
-func getLogEncoder(color bool) zapcore.Encoder {
-	encodeLevel := zapcore.LevelEncoder(zapcore.CapitalLevelEncoder)
-	if color {
-		encodeLevel = zapcore.CapitalColorLevelEncoder
-	}
-	return zapcore.NewConsoleEncoder(zapcore.EncoderConfig{
-		TimeKey:       "ts",
-		LevelKey:      "level",
-		NameKey:       "logger",
-		CallerKey:     "caller",
-		MessageKey:    "msg",
-		StacktraceKey: "stacktrace",
-		LineEnding:    zapcore.DefaultLineEnding,
-		EncodeLevel:   encodeLevel,
-		EncodeTime: func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-			enc.AppendString(t.Format("2006-01-02 15:04:05"))
-		},
-		EncodeDuration: zapcore.SecondsDurationEncoder,
-		EncodeCaller:   zapcore.ShortCallerEncoder,
-	})
+	return writer, nil
 }
 
 func (l *ZapLogger) BeanName() string {
@@ -159,30 +243,62 @@ func (l *ZapLogger) BeanName() string {
 }
 
 func (l *ZapLogger) Named(named string) (logger core.Log) {
-	logger = WrapZapLogger(l.logger.Desugar().Named(named))
-	logger.(*ZapLogger).traceName = l.traceName
-	return
+	derived := l.derive(l.logger.Desugar().Named(named))
+	derived.name = named
+	if l.name != "" {
+		derived.name = l.name + "." + named
+	}
+	return derived
 }
 
 func (l *ZapLogger) Skip(skip int) (logger core.Log) {
 	if skip <= 0 {
-		logger = WrapZapLogger(l.logger.Desugar().WithOptions(zap.WithCaller(false)))
-	} else {
-		// +1 补偿 log helper 的额外栈帧，保持 caller 与 P2-2 前一致
-		logger = WrapZapLogger(l.logger.Desugar().WithOptions(zap.WithCaller(true), zap.AddCallerSkip(skip+1)))
+		return l.derive(l.logger.Desugar().WithOptions(zap.WithCaller(false)))
 	}
-	logger.(*ZapLogger).traceName = l.traceName
-	return
+	// +1 补偿 log helper 的额外栈帧，保持 caller 与 P2-2 前一致
+	return l.derive(l.logger.Desugar().WithOptions(zap.WithCaller(true), zap.AddCallerSkip(skip+1)))
+}
+
+// derive 基于新的 zap logger 派生 ZapLogger，共享追踪配置、级别表与上下文字段提取器。
+func (l *ZapLogger) derive(logger *zap.Logger) *ZapLogger {
+	derived := *l
+	derived.logger = logger.Sugar()
+	return &derived
+}
+
+// ToggleDebug 在 DEBUG 与 INFO 之间切换全局级别（WARN/ERROR 时切换为 DEBUG），返回切换后是否为 DEBUG。
+// Named 前缀级别不受影响；级别在 Named/Skip 派生的 logger 间共享；WrapZapLogger 包装的外部 logger 不支持切换（始终返回 false）。
+// 注意：调用者信息（caller）仅在创建时 log.debug=true 的情况下输出，切换级别不改变这一点。
+func (l *ZapLogger) ToggleDebug() (debug bool) {
+	if l.levels == nil {
+		return false
+	}
+	level := zapcore.DebugLevel
+	if l.levels.rootLevel() == zapcore.DebugLevel {
+		level = zapcore.InfoLevel
+	}
+	l.levels.set("", &level)
+	return level == zapcore.DebugLevel
 }
 
 func (l *ZapLogger) Logger() any {
 	return l.logger.Desugar()
 }
 
-// Close flush 所有日志输出（文件/控制台）。
+// Close 输出采样汇总、flush 所有日志输出（文件/控制台）并关闭文件等输出（之后写入时内置输出会重新打开）；异步写出时先写出缓冲区并停止后台写出，之后的日志同步写入。
 // 实现 dio.Disposable 接口，供容器在 Run 启动失败时清理资源。
 func (l *ZapLogger) Close() error {
-	return l.logger.Desugar().Sync()
+	if sampler := l.sampling.sampler.Load(); sampler != nil {
+		l.stopSampling(sampler)
+	}
+	if l.async != nil {
+		_ = l.async.close()
+	}
+	err := l.logger.Desugar().Sync()
+	for _, sink := range l.sinks {
+		_ = sink.Close()
+	}
+	return err
 }
 
 // Destroy 实现 di.Disposable 接口。
@@ -196,26 +312,19 @@ func (l *ZapLogger) Destroy() {
 // 并符合 Go 的 context key 约定（staticcheck SA1029）。
 type traceContextKey struct{ name string }
 
-func (l *ZapLogger) getTraceId(ctx context.Context) string {
-	if l.traceName != "" {
-		if id, ok := ctx.Value(traceContextKey{name: l.traceName}).(string); ok {
-			return id
-		}
-	}
-	return ""
-}
-
+// Trace ctx 中没有追踪上下文时生成新的（W3C 格式的 trace id 与 span id）。
 func (l *ZapLogger) Trace(ctx context.Context) context.Context {
-	if l.traceName != "" && l.getTraceId(ctx) == "" {
-		ctx = context.WithValue(ctx, traceContextKey{name: l.traceName}, core.UUID())
+	if _, ok := l.TraceContext(ctx); l.traceName != "" && !ok {
+		ctx = context.WithValue(ctx, traceContextKey{name: l.traceName}, NewTraceContext())
 	}
 	return ctx
 }
 
+// TraceWith 使用指定的追踪上下文：val 可为 TraceContext、W3C traceparent 字符串（解析出 trace id 并生成本服务的 span id），
+// 或其他字符串（原值作为 trace id，如上游透传的 X-Request-Id，并生成 span id）；其他类型忽略。
 func (l *ZapLogger) TraceWith(ctx context.Context, val any) context.Context {
-	// 非 string 值无法作为 traceId 输出，忽略之
-	if s, ok := val.(string); ok {
-		ctx = context.WithValue(ctx, traceContextKey{name: l.traceName}, s)
+	if trace, ok := traceContextOf(val); ok && l.traceName != "" {
+		ctx = context.WithValue(ctx, traceContextKey{name: l.traceName}, trace)
 	}
 	return ctx
 }
@@ -232,15 +341,43 @@ func (l *ZapLogger) map2slice(keyAndValues ...map[string]any) (fields []any) {
 	return
 }
 
-// log 内部统一出口：带上 traceId 的 named logger 输出。
+// log 内部统一出口：经级别与采样过滤后，trace id / span id（traceKey/spanKey 字段）、上下文提取字段与调用方字段依次附加，脱敏后输出。
 func (l *ZapLogger) log(ctx context.Context, lvl zapcore.Level, msg string, fields ...any) {
-	logger := l.logger.Named(l.getTraceId(ctx))
+	if l.levels != nil && !l.levels.enabled(l.name, lvl) {
+		return
+	}
+	if !l.logger.Desugar().Core().Enabled(lvl) || !l.sampled(lvl, msg) {
+		return
+	}
+	logger := l.logger
+	msg, fields = l.redactor.redact(msg, append(l.contextFields(ctx), fields...))
 	if len(fields) > 0 {
 		logger = logger.With(fields...)
 	}
 	logger.Log(lvl, msg)
 }
 
+// contextFields 从 ctx 提取随日志输出的字段：trace id / span id 与上下文提取器的字段。
+func (l *ZapLogger) contextFields(ctx context.Context) (fields []any) {
+	if trace, ok := l.TraceContext(ctx); ok {
+		traceKey, spanKey := l.traceKey, l.spanKey
+		if traceKey == "" {
+			traceKey = defaultTraceKey
+		}
+		if spanKey == "" {
+			spanKey = defaultSpanKey
+		}
+		fields = append(fields, traceKey, trace.TraceID)
+		if trace.SpanID != "" {
+			fields = append(fields, spanKey, trace.SpanID)
+		}
+	}
+	if l.extractors != nil {
+		fields = append(fields, l.extractors.extract(ctx)...)
+	}
+	return fields
+}
+
 func (l *ZapLogger) Debug(ctx context.Context, msg string, keyAndValues ...any) {
 	l.log(ctx, zapcore.DebugLevel, msg, keyAndValues...)
 }